@@ -0,0 +1,64 @@
+package gocraft
+
+import (
+	"sync"
+
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+)
+
+type cachedChunk struct {
+	version string
+	blocks  []*blockpb.Block
+}
+
+// chunkCache keeps the last fetched blocks of each chunk together with
+// the version they were fetched at.
+type chunkCache struct {
+	mu     sync.RWMutex
+	chunks map[[2]int32]*cachedChunk
+}
+
+func newChunkCache() *chunkCache {
+	return &chunkCache{
+		chunks: make(map[[2]int32]*cachedChunk),
+	}
+}
+
+func (c *chunkCache) get(p, q int32) (*cachedChunk, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	chunk, ok := c.chunks[[2]int32{p, q}]
+	return chunk, ok
+}
+
+func (c *chunkCache) put(p, q int32, version string, blocks []*blockpb.Block) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chunks[[2]int32{p, q}] = &cachedChunk{
+		version: version,
+		blocks:  blocks,
+	}
+}
+
+// versions lists the cached chunks and their versions.
+func (c *chunkCache) versions() []*blockpb.CachedChunk {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	list := make([]*blockpb.CachedChunk, 0, len(c.chunks))
+	for id, chunk := range c.chunks {
+		list = append(list, &blockpb.CachedChunk{P: id[0], Q: id[1], Version: chunk.version})
+	}
+	return list
+}
+
+func (c *chunkCache) remove(p, q int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.chunks, [2]int32{p, q})
+}
+
+func (c *chunkCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chunks = make(map[[2]int32]*cachedChunk)
+}
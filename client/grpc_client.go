@@ -0,0 +1,405 @@
+package gocraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	authpb "github.com/perlinson/gocraft-server/proto/auth"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	chatpb "github.com/perlinson/gocraft-server/proto/chat"
+	commandpb "github.com/perlinson/gocraft-server/proto/command"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys sent with every call. AuthorizationKey carries the session
+// token, SessionIDKey an ID the server logs to correlate the calls of one
+// client.
+const (
+	AuthorizationKey = "authorization"
+	SessionIDKey     = "x-session-id"
+)
+
+// ErrNotLoggedIn is returned by Logout when no session token is held.
+var ErrNotLoggedIn = errors.New("gocraft: not logged in")
+
+// MoveRejectedError is returned by UpdateState when the server rejected the
+// move, e.g. as too fast. The other players are still returned, the local
+// player must move back to Correction.
+type MoveRejectedError struct {
+	Correction *playerpb.PlayerState
+}
+
+func (e *MoveRejectedError) Error() string {
+	return fmt.Sprintf("gocraft: move rejected, corrected to (%.2f, %.2f, %.2f)", e.Correction.X, e.Correction.Y, e.Correction.Z)
+}
+
+// ConflictError is returned by UpdateBlockIf when the chunk or block
+// changed since the version the update was based on.
+type ConflictError struct {
+	ChunkVersion string
+	// Block is the stored block, W is 0 if there is none.
+	Block *blockpb.Block
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("gocraft: block (%d, %d, %d) was changed concurrently", e.Block.X, e.Block.Y, e.Block.Z)
+}
+
+// GRPCClient talks to the gRPC server. It keeps the session token returned
+// by Login and attaches it to every call, caches chunks by version and
+// resubscribes streams with backoff when the connection drops.
+type GRPCClient struct {
+	conn    *grpc.ClientConn
+	backoff backoff.Config
+
+	Auth    authpb.AuthServiceClient
+	Block   blockpb.BlockServiceClient
+	Player  playerpb.PlayerServiceClient
+	Chat    chatpb.ChatServiceClient
+	Command commandpb.CommandServiceClient
+
+	mu    sync.RWMutex
+	token string
+	user  *authpb.User
+	// world is the world block calls go to, empty for the default world.
+	world string
+
+	blockTypes        []*blockpb.BlockType
+	blockTypesVersion string
+
+	chunks *chunkCache
+
+	// sessionID correlates the server logs of all calls of this client.
+	sessionID string
+}
+
+type grpcOptions struct {
+	dialOptions []grpc.DialOption
+	backoff     backoff.Config
+	token       string
+}
+
+// GRPCOption configures a GRPCClient.
+type GRPCOption func(*grpcOptions)
+
+// WithDialOptions appends raw grpc.DialOption values, e.g. transport
+// credentials. Without them the connection is insecure.
+func WithDialOptions(opts ...grpc.DialOption) GRPCOption {
+	return func(o *grpcOptions) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// WithBackoff sets the backoff used both for reconnecting the underlying
+// connection and for resubscribing broken streams.
+func WithBackoff(cfg backoff.Config) GRPCOption {
+	return func(o *grpcOptions) {
+		o.backoff = cfg
+	}
+}
+
+// WithToken starts the client with an existing session token.
+func WithToken(token string) GRPCOption {
+	return func(o *grpcOptions) {
+		o.token = token
+	}
+}
+
+// NewGRPCClient dials addr. The connection is established lazily and is
+// re-established automatically using the configured backoff.
+func NewGRPCClient(addr string, opts ...GRPCOption) (*GRPCClient, error) {
+	o := &grpcOptions{
+		backoff: backoff.DefaultConfig,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	c := &GRPCClient{
+		backoff: o.backoff,
+		token:   o.token,
+		chunks:  newChunkCache(),
+
+		sessionID: uuid.NewString(),
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           o.backoff,
+			MinConnectTimeout: 5 * time.Second,
+		}),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithChainUnaryInterceptor(c.unaryAuth),
+		grpc.WithChainStreamInterceptor(c.streamAuth),
+	}
+	dialOptions = append(dialOptions, o.dialOptions...)
+
+	conn, err := grpc.NewClient(addr, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	c.Auth = authpb.NewAuthServiceClient(conn)
+	c.Block = blockpb.NewBlockServiceClient(conn)
+	c.Player = playerpb.NewPlayerServiceClient(conn)
+	c.Chat = chatpb.NewChatServiceClient(conn)
+	c.Command = commandpb.NewCommandServiceClient(conn)
+	return c, nil
+}
+
+// Token returns the current session token, empty before Login.
+func (c *GRPCClient) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// User returns the user returned by the last successful Login.
+func (c *GRPCClient) User() *authpb.User {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.user
+}
+
+// Login authenticates and stores the returned token for subsequent calls.
+func (c *GRPCClient) Login(ctx context.Context, username, password string) (*authpb.LoginResponse, error) {
+	resp, err := c.Auth.Login(ctx, &authpb.LoginRequest{
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.token = resp.Token
+	c.user = resp.User
+	c.mu.Unlock()
+	return resp, nil
+}
+
+// Logout invalidates the current token on the server and forgets it.
+func (c *GRPCClient) Logout(ctx context.Context) error {
+	token := c.Token()
+	if token == "" {
+		return ErrNotLoggedIn
+	}
+	if _, err := c.Auth.Logout(ctx, &authpb.LogoutRequest{Token: token}); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.token = ""
+	c.user = nil
+	c.mu.Unlock()
+	return nil
+}
+
+// World returns the world block calls go to, empty for the default world
+// until JoinWorld.
+func (c *GRPCClient) World() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.world
+}
+
+// ListWorlds lists the worlds of the server.
+func (c *GRPCClient) ListWorlds(ctx context.Context) ([]*playerpb.World, error) {
+	resp, err := c.Player.ListWorlds(ctx, &playerpb.ListWorldsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Worlds, nil
+}
+
+// JoinWorld moves player id into world. Later block calls go to that
+// world and the chunk cache is emptied; chunk subscriptions of the old
+// world must be cancelled by the caller.
+func (c *GRPCClient) JoinWorld(ctx context.Context, id, world string) (*playerpb.World, error) {
+	resp, err := c.Player.JoinWorld(ctx, &playerpb.JoinWorldRequest{Id: id, World: world})
+	if err != nil {
+		return nil, err
+	}
+	c.setWorld(resp.World.GetName())
+	return resp.World, nil
+}
+
+// setWorld switches block calls to world and empties the chunk cache.
+func (c *GRPCClient) setWorld(world string) {
+	c.mu.Lock()
+	c.world = world
+	c.mu.Unlock()
+	c.chunks.clear()
+}
+
+// FetchChunk returns the blocks of chunk (p, q) of the current world. The locally cached
+// version is sent along, so unchanged chunks are served from the cache
+// without transferring their blocks again.
+func (c *GRPCClient) FetchChunk(ctx context.Context, p, q int32) ([]*blockpb.Block, string, error) {
+	cached, ok := c.chunks.get(p, q)
+	req := &blockpb.FetchChunkRequest{P: p, Q: q, World: c.World()}
+	if ok {
+		req.Version = cached.version
+	}
+	resp, err := c.Block.FetchChunk(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	if ok && resp.Version == cached.version {
+		return cached.blocks, cached.version, nil
+	}
+	c.chunks.put(p, q, resp.Version, resp.Blocks)
+	return resp.Blocks, resp.Version, nil
+}
+
+// BlockTypes returns the block registry of the server. It is cached and
+// only downloaded again when the server's registry changed.
+func (c *GRPCClient) BlockTypes(ctx context.Context) ([]*blockpb.BlockType, error) {
+	c.mu.RLock()
+	version := c.blockTypesVersion
+	c.mu.RUnlock()
+	resp, err := c.Block.ListBlockTypes(ctx, &blockpb.ListBlockTypesRequest{Version: version})
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if resp.Version != c.blockTypesVersion {
+		c.blockTypes = resp.Types
+		c.blockTypesVersion = resp.Version
+	}
+	return c.blockTypes, nil
+}
+
+// InvalidateChunk drops chunk (p, q) from the cache.
+func (c *GRPCClient) InvalidateChunk(p, q int32) {
+	c.chunks.remove(p, q)
+}
+
+// UpdateBlock sets block (x, y, z) of chunk (p, q) to w and returns the
+// new chunk version. The server rejects a (p, q) that does not contain the
+// block.
+func (c *GRPCClient) UpdateBlock(ctx context.Context, p, q, x, y, z, w int32) (string, error) {
+	return c.UpdateBlockIf(ctx, blockpb.UpdateBlockRequest_NONE, "", p, q, x, y, z, w)
+}
+
+// UpdateBlockIf is UpdateBlock that only writes if version is still the
+// chunk or block version, depending on precondition. When another edit
+// came first it returns a *ConflictError with the current state.
+func (c *GRPCClient) UpdateBlockIf(ctx context.Context, precondition blockpb.UpdateBlockRequest_Precondition, version string, p, q, x, y, z, w int32) (string, error) {
+	resp, err := c.Block.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{
+		P:            p,
+		Q:            q,
+		X:            x,
+		Y:            y,
+		Z:            z,
+		W:            w,
+		Version:      version,
+		Precondition: precondition,
+		World:        c.World(),
+	})
+	if err != nil {
+		for _, detail := range status.Convert(err).Details() {
+			if conflict, ok := detail.(*blockpb.UpdateConflict); ok {
+				c.chunks.remove(p, q)
+				return "", &ConflictError{ChunkVersion: conflict.ChunkVersion, Block: conflict.Block}
+			}
+		}
+		return "", err
+	}
+	// the cached copies no longer match the server, refetch on next access.
+	c.chunks.remove(p, q)
+	for _, n := range resp.Neighbors {
+		c.chunks.remove(n.P, n.Q)
+	}
+	return resp.Version, nil
+}
+
+// UpdateBlocks writes a batch of blocks, given by x, y, z and w, in one
+// transaction and returns the new version of the affected chunks.
+func (c *GRPCClient) UpdateBlocks(ctx context.Context, blocks []*blockpb.Block) (string, error) {
+	resp, err := c.Block.UpdateBlocks(ctx, &blockpb.UpdateBlocksRequest{Blocks: blocks, World: c.World()})
+	if err != nil {
+		return "", err
+	}
+	for _, id := range resp.Chunks {
+		c.chunks.remove(id.P, id.Q)
+	}
+	return resp.Version, nil
+}
+
+// UpdateView moves view id, opened with SubscribeView, to block position
+// (x, z) and sets its view distance in chunks.
+func (c *GRPCClient) UpdateView(ctx context.Context, id string, x, z float32, distance int32) error {
+	_, err := c.Block.UpdateView(ctx, &blockpb.UpdateViewRequest{Id: id, X: x, Z: z, Distance: distance})
+	return err
+}
+
+// UpdateState reports the state of player id and returns the states of
+// all other players. A rejected move returns them together with a
+// *MoveRejectedError, as does a teleport by the server. When the server
+// moved the player into another world, later block calls go to it like
+// after JoinWorld.
+func (c *GRPCClient) UpdateState(ctx context.Context, id string, state *playerpb.PlayerState) (map[string]*playerpb.PlayerState, error) {
+	resp, err := c.Player.UpdateState(ctx, &playerpb.UpdateStateRequest{
+		Id:    id,
+		State: state,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.World != "" {
+		c.setWorld(resp.World)
+	}
+	if resp.Correction != nil {
+		return resp.Players, &MoveRejectedError{Correction: resp.Correction}
+	}
+	return resp.Players, nil
+}
+
+// RemovePlayer tells the server that player id has left.
+func (c *GRPCClient) RemovePlayer(ctx context.Context, id string) error {
+	_, err := c.Player.RemovePlayer(ctx, &playerpb.RemovePlayerRequest{Id: id})
+	return err
+}
+
+// Heartbeat keeps player id online while it is not sending state updates.
+func (c *GRPCClient) Heartbeat(ctx context.Context, id string) error {
+	_, err := c.Player.Heartbeat(ctx, &playerpb.HeartbeatRequest{Id: id})
+	return err
+}
+
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *GRPCClient) withToken(ctx context.Context) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx, SessionIDKey, c.sessionID)
+	token := c.Token()
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, AuthorizationKey, "Bearer "+token)
+}
+
+func (c *GRPCClient) unaryAuth(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(c.withToken(ctx), method, req, reply, cc, opts...)
+}
+
+func (c *GRPCClient) streamAuth(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(c.withToken(ctx), desc, cc, method, opts...)
+}
@@ -0,0 +1,80 @@
+package gocraft_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	gocraft "github.com/perlinson/gocraft-server/client"
+	authpb "github.com/perlinson/gocraft-server/proto/auth"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeAuth struct {
+	authpb.UnimplementedAuthServiceServer
+}
+
+func (fakeAuth) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.LoginResponse, error) {
+	return &authpb.LoginResponse{Token: "token-" + req.Username, User: &authpb.User{Id: "1", Name: req.Username}}, nil
+}
+
+type fakeBlock struct {
+	blockpb.UnimplementedBlockServiceServer
+	tokens []string
+	sent   int
+}
+
+func (s *fakeBlock) FetchChunk(ctx context.Context, req *blockpb.FetchChunkRequest) (*blockpb.FetchChunkResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.tokens = append(s.tokens, md.Get(gocraft.AuthorizationKey)...)
+	if req.Version == "v1" {
+		return &blockpb.FetchChunkResponse{Version: "v1"}, nil
+	}
+	s.sent++
+	return &blockpb.FetchChunkResponse{
+		Version: "v1",
+		Blocks:  []*blockpb.Block{{X: 1, Y: 2, Z: 3, W: 4}},
+	}, nil
+}
+
+func TestGRPCClient(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	block := &fakeBlock{}
+	authpb.RegisterAuthServiceServer(srv, fakeAuth{})
+	blockpb.RegisterBlockServiceServer(srv, block)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	c, err := gocraft.NewGRPCClient("passthrough:///bufnet", gocraft.WithDialOptions(
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	))
+	assert.NoError(t, err)
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err = c.Login(ctx, "steve", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-steve", c.Token())
+
+	for i := 0; i < 3; i++ {
+		blocks, version, err := c.FetchChunk(ctx, 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", version)
+		assert.Len(t, blocks, 1)
+	}
+	// only the first fetch transferred blocks, the rest hit the cache.
+	assert.Equal(t, 1, block.sent)
+	assert.Equal(t, []string{"Bearer token-steve", "Bearer token-steve", "Bearer token-steve"}, block.tokens)
+
+	c.InvalidateChunk(0, 0)
+	_, _, err = c.FetchChunk(ctx, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, block.sent)
+}
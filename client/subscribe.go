@@ -0,0 +1,277 @@
+package gocraft
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	chatpb "github.com/perlinson/gocraft-server/proto/chat"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PlayerEventType tells what happened to a remote player.
+type PlayerEventType int
+
+const (
+	PlayerJoined PlayerEventType = iota
+	PlayerMoved
+	PlayerLeft
+	// PlayerCorrected is about the local player: the server rejected its
+	// move and State is where it must snap back to.
+	PlayerCorrected
+)
+
+func (t PlayerEventType) String() string {
+	switch t {
+	case PlayerJoined:
+		return "joined"
+	case PlayerMoved:
+		return "moved"
+	case PlayerLeft:
+		return "left"
+	case PlayerCorrected:
+		return "corrected"
+	}
+	return "unknown"
+}
+
+// PlayerEvent is delivered to player subscription handlers. State is nil
+// for PlayerLeft, Reason is only set for PlayerLeft events pushed by the
+// server.
+type PlayerEvent struct {
+	Type   PlayerEventType
+	ID     string
+	State  *playerpb.PlayerState
+	Reason string
+}
+
+var playerEventTypes = map[playerpb.PlayerEvent_Type]PlayerEventType{
+	playerpb.PlayerEvent_JOIN:   PlayerJoined,
+	playerpb.PlayerEvent_UPDATE: PlayerMoved,
+	playerpb.PlayerEvent_LEAVE:  PlayerLeft,
+}
+
+// SubscribeChunk streams updates of chunk (p, q) of the current world to f
+// until ctx is done. Broken streams are reopened with backoff. Each update
+// also invalidates the cached copy of the chunk.
+func (c *GRPCClient) SubscribeChunk(ctx context.Context, p, q int32, f func(*blockpb.ChunkUpdate)) error {
+	world := c.World()
+	version := ""
+	if cached, ok := c.chunks.get(p, q); ok {
+		version = cached.version
+	}
+
+	retries := 0
+	for {
+		stream, err := c.Block.StreamChunk(ctx, &blockpb.ChunkRequest{P: p, Q: q, Version: version, World: world})
+		for err == nil {
+			var update *blockpb.ChunkUpdate
+			update, err = stream.Recv()
+			if err != nil {
+				break
+			}
+			retries = 0
+			version = update.Version
+			c.chunks.remove(update.P, update.Q)
+			f(update)
+		}
+		if werr := c.retry(ctx, err, retries); werr != nil {
+			return werr
+		}
+		retries++
+	}
+}
+
+// SubscribeView streams the chunks around a position of the current world
+// to f until ctx is done: LOAD with the blocks of chunks coming into view,
+// nearest first, UNLOAD for chunks leaving it and UPDATE for their edits.
+// The view starts at the position and distance returned by view and is
+// moved with UpdateView. Loaded chunks are cached, so LOADs of unchanged
+// chunks are filled from the cache. Broken streams are reopened with
+// backoff at the position view returns then.
+func (c *GRPCClient) SubscribeView(ctx context.Context, id string, view func() (x, z float32, distance int32), f func(*blockpb.ViewUpdate)) error {
+	retries := 0
+	for {
+		x, z, distance := view()
+		stream, err := c.Block.StreamView(ctx, &blockpb.StreamViewRequest{
+			Id:       id,
+			World:    c.World(),
+			X:        x,
+			Z:        z,
+			Distance: distance,
+			Cached:   c.chunks.versions(),
+		})
+		for err == nil {
+			var update *blockpb.ViewUpdate
+			update, err = stream.Recv()
+			if err != nil {
+				break
+			}
+			retries = 0
+			switch update.Type {
+			case blockpb.ViewUpdate_LOAD:
+				if update.Cached {
+					update.Blocks, _, err = c.FetchChunk(ctx, update.P, update.Q)
+					if err != nil {
+						continue
+					}
+				} else {
+					c.chunks.put(update.P, update.Q, update.Version, update.Blocks)
+				}
+			case blockpb.ViewUpdate_UPDATE:
+				c.chunks.remove(update.P, update.Q)
+			}
+			f(update)
+		}
+		if werr := c.retry(ctx, err, retries); werr != nil {
+			return werr
+		}
+		retries++
+	}
+}
+
+// SubscribePlayers reports the state returned by state every interval as
+// player id and turns the answers into join, move and leave events for f.
+// It runs until ctx is done, retrying failed calls with backoff.
+func (c *GRPCClient) SubscribePlayers(ctx context.Context, id string, interval time.Duration, state func() *playerpb.PlayerState, f func(PlayerEvent)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	known := make(map[string]*playerpb.PlayerState)
+	retries := 0
+	for {
+		players, err := c.UpdateState(ctx, id, state())
+		var rejected *MoveRejectedError
+		if errors.As(err, &rejected) {
+			f(PlayerEvent{Type: PlayerCorrected, ID: id, State: rejected.Correction})
+			err = nil
+		}
+		if err != nil {
+			if werr := c.retry(ctx, err, retries); werr != nil {
+				return werr
+			}
+			retries++
+			continue
+		}
+		retries = 0
+
+		for pid, pstate := range players {
+			if _, ok := known[pid]; ok {
+				f(PlayerEvent{Type: PlayerMoved, ID: pid, State: pstate})
+			} else {
+				f(PlayerEvent{Type: PlayerJoined, ID: pid, State: pstate})
+			}
+		}
+		for pid := range known {
+			if _, ok := players[pid]; !ok {
+				f(PlayerEvent{Type: PlayerLeft, ID: pid})
+			}
+		}
+		known = players
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SubscribePlayerEvents streams the join, move and leave events the server
+// pushes about players other than id to f until ctx is done. Broken streams
+// are reopened with backoff.
+func (c *GRPCClient) SubscribePlayerEvents(ctx context.Context, id string, f func(PlayerEvent)) error {
+	retries := 0
+	for {
+		stream, err := c.Player.StreamEvents(ctx, &playerpb.StreamEventsRequest{Id: id})
+		for err == nil {
+			var event *playerpb.PlayerEvent
+			event, err = stream.Recv()
+			if err != nil {
+				break
+			}
+			retries = 0
+			f(PlayerEvent{
+				Type:   playerEventTypes[event.Type],
+				ID:     event.Id,
+				State:  event.State,
+				Reason: event.Reason,
+			})
+		}
+		if werr := c.retry(ctx, err, retries); werr != nil {
+			return werr
+		}
+		retries++
+	}
+}
+
+// SubscribeChat streams the chat messages the logged in user can read to
+// f until ctx is done, local chat around player. Broken streams are
+// reopened with backoff; messages sent meanwhile are missed.
+func (c *GRPCClient) SubscribeChat(ctx context.Context, player string, f func(*chatpb.ChatMessage)) error {
+	retries := 0
+	for {
+		stream, err := c.Chat.StreamMessages(ctx, &chatpb.StreamMessagesRequest{Player: player})
+		for err == nil {
+			var msg *chatpb.ChatMessage
+			msg, err = stream.Recv()
+			if err != nil {
+				break
+			}
+			retries = 0
+			f(msg)
+		}
+		if werr := c.retry(ctx, err, retries); werr != nil {
+			return werr
+		}
+		retries++
+	}
+}
+
+// retry waits before the next attempt after err. It returns err instead
+// when the server ended the call for good: a kick (Aborted) or a ban
+// (PermissionDenied) would only be repeated.
+func (c *GRPCClient) retry(ctx context.Context, err error, retries int) error {
+	switch status.Code(err) {
+	case codes.Aborted, codes.PermissionDenied:
+		return err
+	}
+	return c.wait(ctx, retries)
+}
+
+// wait sleeps for the backoff delay of the given retry count.
+func (c *GRPCClient) wait(ctx context.Context, retries int) error {
+	timer := time.NewTimer(backoffDelay(c.backoff, retries))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoffDelay mirrors the exponential backoff grpc uses for reconnects.
+func backoffDelay(cfg backoff.Config, retries int) time.Duration {
+	if retries == 0 {
+		return cfg.BaseDelay
+	}
+	delay, max := float64(cfg.BaseDelay), float64(cfg.MaxDelay)
+	for delay < max && retries > 0 {
+		delay *= cfg.Multiplier
+		retries--
+	}
+	if delay > max {
+		delay = max
+	}
+	delay *= 1 + cfg.Jitter*(rand.Float64()*2-1)
+	if delay < 0 {
+		return 0
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/perlinson/gocraft-server/internal/backup"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+)
+
+const backupUsage = `usage:
+  server backup snapshot [flags] [file]
+  server backup restore [flags] file
+
+snapshot writes a consistent snapshot of the store, also while the server
+runs, to file or into backup.dir. restore reads one into an empty
+database; stop the server first.`
+
+// runBackup 实现 backup 子命令
+func runBackup(args []string) {
+	if len(args) == 0 || (args[0] != "snapshot" && args[0] != "restore") {
+		fmt.Fprintln(os.Stderr, backupUsage)
+		os.Exit(2)
+	}
+	cmd := args[0]
+
+	fs := flag.NewFlagSet("backup "+cmd, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, backupUsage)
+		fs.PrintDefaults()
+	}
+	cfg, err := config.Load(fs, args[1:])
+	if err != nil {
+		fatal("loading configuration failed", err)
+	}
+	if fs.NArg() > 1 || (cmd == "restore" && fs.NArg() != 1) {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	level, _ := logging.ParseLevel(cfg.Log.Level)
+	logging.Setup(os.Stderr, "text", level)
+	store, err := Store.InitStore(cfg.Storage)
+	if err != nil {
+		fatal("initializing store failed", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	switch cmd {
+	case "snapshot":
+		if fs.NArg() == 0 {
+			if _, err := backup.NewManager(store, cfg.Backup.Dir, cfg.Backup.Keep).Snapshot(ctx); err != nil {
+				fatal("snapshot failed", err)
+			}
+			return
+		}
+		path := fs.Arg(0)
+		f, err := os.Create(path)
+		if err != nil {
+			fatal("creating snapshot file failed", err)
+		}
+		counts, err := backup.Snapshot(ctx, store, f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(path)
+			fatal("snapshot failed", err)
+		}
+		slog.Info("snapshot written", "file", path, "rows", counts)
+
+	case "restore":
+		path := fs.Arg(0)
+		f, err := os.Open(path)
+		if err != nil {
+			fatal("opening snapshot failed", err)
+		}
+		defer f.Close()
+		counts, err := backup.Restore(ctx, store, f)
+		if err != nil {
+			fatal("restore failed", err)
+		}
+		slog.Info("snapshot restored", "file", path, "rows", counts)
+	}
+}
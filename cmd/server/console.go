@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"google.golang.org/grpc/status"
+)
+
+// runConsole 逐行读取 in 中的命令，以控制台身份执行，输出写到 out，直到 in 结束
+func runConsole(ctx context.Context, in io.Reader, out io.Writer, registry *commands.Registry) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		output, err := registry.Execute(ctx, commands.ConsoleSender, line)
+		if err != nil {
+			fmt.Fprintln(out, status.Convert(err).Message())
+			continue
+		}
+		if output != "" {
+			fmt.Fprintln(out, output)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Warn("reading console failed", "error", err)
+	}
+}
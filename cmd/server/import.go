@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/gocraftdb"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+)
+
+const importUsage = `usage:
+  server import-gocraft [flags] world.db
+
+Imports the blocks, chunk versions and camera of a single-player gocraft
+world database into a world of the configured store, by default "world". Quit gocraft first, it locks
+the file, and stop the server, its chunk cache would hide the import.`
+
+// runImport 实现 import-gocraft 子命令
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import-gocraft", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, importUsage)
+		fs.PrintDefaults()
+	}
+	name := fs.String("world", Store.DefaultWorld, "the world to import into")
+	cfg, err := config.Load(fs, args)
+	if err != nil {
+		fatal("loading configuration failed", err)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	path := fs.Arg(0)
+
+	level, _ := logging.ParseLevel(cfg.Log.Level)
+	logging.Setup(os.Stderr, "text", level)
+	checkWorld(cfg, *name)
+	world, err := gocraftdb.Read(path)
+	if err != nil {
+		fatal("reading gocraft world failed", err)
+	}
+	store, err := Store.InitStore(cfg.Storage)
+	if err != nil {
+		fatal("initializing store failed", err)
+	}
+	defer store.Close()
+
+	stats, err := gocraftdb.Import(store, *name, world)
+	if err != nil {
+		fatal("importing gocraft world failed", err)
+	}
+	slog.Info("gocraft world imported", "file", path, "world", *name, "blocks", stats.Blocks, "chunk_versions", stats.Versions, "camera", stats.Camera)
+}
@@ -1,52 +1,331 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
 	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
-	authpb "github.com/perlinson/gocraft-server/internal/proto/auth"
-	blockpb "github.com/perlinson/gocraft-server/internal/proto/block"
-	playerpb "github.com/perlinson/gocraft-server/internal/proto/player"
+	authpb "github.com/perlinson/gocraft-server/proto/auth"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	chatpb "github.com/perlinson/gocraft-server/proto/chat"
+	commandpb "github.com/perlinson/gocraft-server/proto/command"
+	editpb "github.com/perlinson/gocraft-server/proto/edit"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
 	Store "github.com/perlinson/gocraft-server/internal/store"
 
+	"github.com/gin-gonic/gin"
+	server "github.com/perlinson/gocraft-server"
+	"github.com/perlinson/gocraft-server/internal/backup"
+	"github.com/perlinson/gocraft-server/internal/blocks"
+	"github.com/perlinson/gocraft-server/internal/chat"
+	"github.com/perlinson/gocraft-server/internal/chunkcache"
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/gateway"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/metrics"
+	"github.com/perlinson/gocraft-server/internal/middleware"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
 	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/worlds"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 )
 
-// var (
-// 	listenAddr = flag.String("l", "0.0.0.0:8421", "listen address")
-// )
+// fatal logs err and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// loadRegistry returns the configured block registry.
+func loadRegistry(cfg *config.Config) *blocks.Registry {
+	if cfg.World.Blocks == "" {
+		return blocks.Default()
+	}
+	registry, err := blocks.Load(cfg.World.Blocks)
+	if err != nil {
+		fatal("loading block registry failed", err)
+	}
+	return registry
+}
+
+// checkWorld exits unless the configuration has a world called name.
+func checkWorld(cfg *config.Config, name string) {
+	if worlds.New(cfg.World).Lookup(name) == nil {
+		fatal("unknown world", fmt.Errorf("%q is not configured in world.worlds", name))
+	}
+}
 
 func main() {
-	flag.Parse()
+	// 子命令
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "schematic":
+			runSchematic(os.Args[2:])
+			return
+		case "backup":
+			runBackup(os.Args[2:])
+			return
+		case "import-gocraft":
+			runImport(os.Args[2:])
+			return
+		}
+	}
 
-	// 注释掉InitStore调用，根据用户要求不需要关心这部分
-	store, err := Store.InitStore()
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
 	if err != nil {
-		log.Fatal(err)
+		fatal("loading configuration failed", err)
+	}
+
+	// 日志级别已由配置校验过
+	level, _ := logging.ParseLevel(cfg.Log.Level)
+	levelVar := logging.Setup(os.Stdout, cfg.Log.Format, level)
+	if level > slog.LevelDebug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	store, err := Store.InitStore(cfg.Storage)
+	if err != nil {
+		fatal("initializing store failed", err)
+	}
+	if err := store.DB.Use(metrics.GormPlugin{}); err != nil {
+		fatal("registering store metrics failed", err)
+	}
+
+	registry := loadRegistry(cfg)
+	slog.Info("block registry loaded", "types", len(registry.Types()), "version", registry.Version())
+	worldRegistry := worlds.New(cfg.World)
+	for _, w := range worldRegistry.All() {
+		slog.Info("world configured", "world", w.Name, "seed", w.Seed, "generator", w.Generator)
 	}
-	// 创建gRPC服务器
-	grpcServer := grpc.NewServer()
 
 	// 初始化各服务
 	blockService := services.NewBlockService(store)
+	blockService.SetBlockRegistry(registry)
+	blockService.SetWorlds(worldRegistry)
 	playerService := services.NewPlayerService(nil) // 暂时传入nil
-	authService := services.NewAuthService(nil)     // 暂时传入nil
+	playerService.SetBlockRegistry(registry)
+	playerService.SetWorlds(worldRegistry, store)
+
+	// 区块缓存，修改定期写入数据库
+	var terrain services.BlockReader = store
+	var cache *chunkcache.Cache
+	if c := cfg.Cache; c.MaxChunks > 0 {
+		cache = chunkcache.New(store, c.MaxChunks, c.MaxBlocks)
+		blockService.SetChunkCache(cache)
+		terrain = cache
+		metrics.RegisterChunkCache(cache.Len)
+		go cache.Run(context.Background(), c.FlushInterval.Duration)
+		slog.Info("chunk cache enabled", "max_chunks", c.MaxChunks, "max_blocks", c.MaxBlocks, "flush_interval", c.FlushInterval)
+	}
+	if m := cfg.Movement; m.Validate {
+		playerService.SetMovementRules(services.MovementRules{
+			MaxSpeed:     m.MaxSpeed,
+			MaxRiseSpeed: m.MaxRiseSpeed,
+			MaxFallSpeed: m.MaxFallSpeed,
+			Tolerance:    m.Tolerance,
+		}, terrain)
+	}
+	editService := services.NewEditService(store, blockService, cfg.Edit.Limits, cfg.Edit.UndoDepth)
+	chatService := services.NewChatService(store, playerService)
+	chatService.SetFilter(chat.NewFilter(cfg.Chat.Filter))
+	chatService.SetLimits(cfg.Chat.MaxLength, cfg.Chat.LocalRadius)
+	if cfg.Chat.LogRetention.Duration > 0 {
+		go chatService.PruneLog(context.Background(), cfg.Chat.LogRetention.Duration, time.Hour)
+	}
+
+	authService := services.NewAuthService(store)
+	authService.SetTokenTTL(cfg.Auth.TokenTTL.Duration)
+	authService.SetLockout(cfg.Auth.MaxFailedLogins, cfg.Auth.LockoutDuration.Duration)
 
-	// 注册服务
-	blockpb.RegisterBlockServiceServer(grpcServer, blockService)
-	playerpb.RegisterPlayerServiceServer(grpcServer, playerService)
-	authpb.RegisterAuthServiceServer(grpcServer, authService)
+	// 封禁、踢出与白名单，登录时检查
+	moderation := services.NewModerationService(store, authService, playerService)
+	moderation.SetWhitelist(cfg.Auth.Whitelist)
+	authService.SetAdmission(moderation.Admit)
+
+	// 斜杠命令，来自聊天、CommandService 和控制台
+	commandRegistry := commands.NewRegistry(cfg.Commands.Permissions)
+	if err := playerService.RegisterCommands(commandRegistry); err != nil {
+		fatal("registering commands failed", err)
+	}
+	if err := chatService.RegisterCommands(commandRegistry); err != nil {
+		fatal("registering commands failed", err)
+	}
+	if err := moderation.RegisterCommands(commandRegistry); err != nil {
+		fatal("registering commands failed", err)
+	}
+	chatService.SetCommands(commandRegistry)
+	commandService := services.NewCommandService(store, commandRegistry)
+	if cfg.Commands.Console {
+		go runConsole(context.Background(), os.Stdin, os.Stdout, commandRegistry)
+	}
+
+	// 限流
+	limiter := ratelimit.New(cfg.RateLimit)
+	go limiter.Prune(context.Background(), time.Minute)
+	authService.SetRateLimiter(limiter)
+	go authService.PruneFailures(context.Background(), time.Minute)
+	blockService.SetRateLimiter(limiter)
+	blockService.SetEditLimits(cfg.Edit.Limits)
+	chatService.SetRateLimiter(limiter)
+
+	// 创建gRPC服务器
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(authService.ResolveUser),
+			moderation.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+			limiter.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			logging.StreamServerInterceptor(authService.ResolveUser),
+			moderation.StreamServerInterceptor(),
+			metrics.StreamServerInterceptor(),
+		),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    cfg.Keepalive.Interval.Duration,
+			Timeout: cfg.Keepalive.Timeout.Duration,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             5 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	)
+
+	// 注册服务，都依赖存储，数据库不可达时健康检查把它们标记为 NOT_SERVING
+	healthChecker := services.NewHealthChecker(store)
+	healthChecker.Register(grpcServer, &blockpb.BlockService_ServiceDesc, blockService)
+	healthChecker.Register(grpcServer, &playerpb.PlayerService_ServiceDesc, playerService)
+	healthChecker.Register(grpcServer, &authpb.AuthService_ServiceDesc, authService)
+	healthChecker.Register(grpcServer, &editpb.EditService_ServiceDesc, editService)
+	healthChecker.Register(grpcServer, &chatpb.ChatService_ServiceDesc, chatService)
+	healthChecker.Register(grpcServer, &commandpb.CommandService_ServiceDesc, commandService)
+
+	// 健康检查与反射
+	healthpb.RegisterHealthServer(grpcServer, healthChecker)
+	go healthChecker.Run(context.Background(), cfg.GRPC.HealthCheckInterval.Duration)
+	if cfg.GRPC.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	metrics.RegisterOnlinePlayers("grpc", func() int { return len(playerService.Players()) })
+
+	// 定期快照
+	backups := backup.NewManager(store, cfg.Backup.Dir, cfg.Backup.Keep)
+	if cache != nil {
+		backups.SetFlush(cache.Flush)
+	}
+	if cfg.Backup.Interval.Duration > 0 {
+		go backups.Run(context.Background(), cfg.Backup.Interval.Duration)
+	}
+
+	// 定期清理掉线玩家
+	idle := cfg.Keepalive.IdleTimeout.Duration
+	go playerService.ReapIdle(context.Background(), idle, idle/4)
+
+	// HTTP 接口与浏览器 WebSocket 网关
+	if cfg.Listen.HTTP != "" {
+		router := gin.New()
+		router.Use(gin.Recovery(), middleware.RequestLogger(), middleware.CORS(cfg.HTTP.CORSOrigins), moderation.Middleware())
+		authService.RegisterRoutes(router)
+		blockService.RegisterRoutes(router, authService.RequireAuth())
+		editService.RegisterRoutes(router, authService.RequireAuth())
+		chatService.RegisterRoutes(router, authService.RequireAuth())
+		commandService.RegisterRoutes(router, authService.RequireAuth())
+		playerService.RegisterRoutes(router)
+		metrics.RegisterRoutes(router)
+		gw := gateway.NewGateway(blockService, playerService, authService)
+		gw.SetRateLimiter(limiter)
+		gw.SetAllowedOrigins(cfg.HTTP.CORSOrigins)
+		gw.SetModeration(moderation)
+		gw.SetChatService(chatService)
+		gw.SetCommandService(commandService)
+		gw.RegisterRoutes(router)
+		if cfg.Admin.Token != "" {
+			admin := middleware.AdminToken(cfg.Admin.Token)
+			logging.RegisterRoutes(router, levelVar, admin)
+			playerService.RegisterAdminRoutes(router, admin)
+			authService.RegisterAdminRoutes(router, admin)
+			editService.RegisterAdminRoutes(router, admin)
+			chatService.RegisterAdminRoutes(router, admin)
+			commandService.RegisterAdminRoutes(router, admin)
+			moderation.RegisterAdminRoutes(router, admin)
+			backups.RegisterRoutes(router, admin)
+		}
+		go func() {
+			slog.Info("HTTP server started", "addr", cfg.Listen.HTTP)
+			if err := router.Run(cfg.Listen.HTTP); err != nil {
+				fatal("serving http failed", err)
+			}
+		}()
+	}
+
+	// 旧版 yamux/JSON-RPC 协议
+	if cfg.Listen.Legacy != "" {
+		legacy := server.NewServer()
+		metrics.RegisterOnlinePlayers("legacy", legacy.SessionCount)
+		legacy.SetKeepAlive(cfg.Keepalive.Interval.Duration, cfg.Keepalive.Timeout.Duration)
+		legacy.SetAdmission(moderation.AdmitLegacy)
+		moderation.SetLegacy(legacy)
+		legacyChat := services.NewLegacyChat(chatService, authService.ResolveUser)
+		if err := legacy.RegisterService("Chat", legacyChat); err != nil {
+			fatal("registering legacy chat failed", err)
+		}
+		legacy.SetPlayerCallback(func(event string, id int32) {
+			player := strconv.Itoa(int(id))
+			switch event {
+			case "online":
+				if sess, ok := legacy.Session(id); ok {
+					legacyChat.Connect(player, sess)
+				}
+			case "offline":
+				legacyChat.Disconnect(player)
+				playerService.RemovePlayer(context.Background(), &playerpb.RemovePlayerRequest{Id: player})
+			}
+		})
+		go legacy.ReapIdle(context.Background(), idle, idle/4)
+
+		l, err := net.Listen("tcp", cfg.Listen.Legacy)
+		if err != nil {
+			fatal("legacy listen failed", err)
+		}
+		slog.Info("legacy server started", "addr", cfg.Listen.Legacy)
+		go legacy.Serve(l)
+	}
 
 	// 启动监听
-	lis, err := net.Listen("tcp", ":50051")
+	lis, err := net.Listen("tcp", cfg.Listen.GRPC)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		fatal("grpc listen failed", err)
 	}
 
-	log.Println("Server started on :50051")
+	// 收到信号后停止 gRPC 服务，把缓存中的修改写入数据库后退出
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		slog.Info("shutting down", "signal", (<-sig).String())
+		grpcServer.Stop()
+	}()
+
+	slog.Info("gRPC server started", "addr", cfg.Listen.GRPC)
 	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+		fatal("serving grpc failed", err)
+	}
+	if cache != nil {
+		if err := cache.Close(); err != nil {
+			fatal("writing cached chunks failed", err)
+		}
+		slog.Info("cached chunks written")
 	}
+	store.Close()
 }
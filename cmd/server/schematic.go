@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/schematic"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+)
+
+const schematicUsage = `usage:
+  server schematic export [flags] [-world name] -from x,y,z -to x,y,z file
+  server schematic import [flags] [-world name] -at x,y,z [-rotation 90] file
+
+The configuration flags of the server select the database and the block
+registry. Imports write the database directly; import while the server is
+stopped, its chunk cache would hide them, or use the admin HTTP routes.`
+
+// runSchematic 实现 schematic 子命令
+func runSchematic(args []string) {
+	if len(args) == 0 || (args[0] != "export" && args[0] != "import") {
+		fmt.Fprintln(os.Stderr, schematicUsage)
+		os.Exit(2)
+	}
+	cmd := args[0]
+
+	fs := flag.NewFlagSet("schematic "+cmd, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, schematicUsage)
+		fs.PrintDefaults()
+	}
+	from := fs.String("from", "", "export: a corner of the region, x,y,z")
+	to := fs.String("to", "", "export: the opposite corner, x,y,z")
+	at := fs.String("at", "", "import: where the minimum corner goes, x,y,z")
+	rotation := fs.Int("rotation", 0, "import: clockwise rotation in degrees, a multiple of 90")
+	world := fs.String("world", Store.DefaultWorld, "the world to export from or import into")
+	cfg, err := config.Load(fs, args[1:])
+	if err != nil {
+		fatal("loading configuration failed", err)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	path := fs.Arg(0)
+
+	level, _ := logging.ParseLevel(cfg.Log.Level)
+	logging.Setup(os.Stderr, "text", level)
+	registry := loadRegistry(cfg)
+	checkWorld(cfg, *world)
+	store, err := Store.InitStore(cfg.Storage)
+	if err != nil {
+		fatal("initializing store failed", err)
+	}
+	defer store.Close()
+
+	switch cmd {
+	case "export":
+		p1, err := Store.ParseVec3(*from)
+		if err != nil {
+			fatal("invalid -from", err)
+		}
+		p2, err := Store.ParseVec3(*to)
+		if err != nil {
+			fatal("invalid -to", err)
+		}
+		lo := Store.Vec3{X: min(p1.X, p2.X), Y: min(p1.Y, p2.Y), Z: min(p1.Z, p2.Z)}
+		hi := Store.Vec3{X: max(p1.X, p2.X), Y: max(p1.Y, p2.Y), Z: max(p1.Z, p2.Z)}
+		sc, err := schematic.Export(store, *world, lo, hi)
+		if err != nil {
+			fatal("exporting schematic failed", err)
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			fatal("creating schematic file failed", err)
+		}
+		if err := sc.Write(f, registry); err != nil {
+			fatal("writing schematic failed", err)
+		}
+		if err := f.Close(); err != nil {
+			fatal("writing schematic failed", err)
+		}
+		slog.Info("schematic exported", "file", path, "world", *world, "blocks", len(sc.Blocks), "size", sc.Size)
+
+	case "import":
+		pos, err := Store.ParseVec3(*at)
+		if err != nil {
+			fatal("invalid -at", err)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			fatal("opening schematic file failed", err)
+		}
+		sc, err := schematic.Read(f, registry)
+		f.Close()
+		if err != nil {
+			fatal("reading schematic failed", err)
+		}
+		changes, err := sc.Place(pos, int32(*rotation))
+		if err != nil {
+			fatal("placing schematic failed", err)
+		}
+		version := Store.GenerateChunkVersion()
+		if _, err := store.UpdateBlocks(*world, changes, version); err != nil {
+			fatal("storing schematic failed", err)
+		}
+		slog.Info("schematic imported", "file", path, "world", *world, "at", pos, "blocks", len(changes), "version", version)
+	}
+}
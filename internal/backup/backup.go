@@ -0,0 +1,250 @@
+// Package backup writes consistent snapshots of the whole store while the
+// server runs and restores them into an empty store.
+//
+// A snapshot is gzip compressed JSON lines. The first line describes the
+// archive, each following line holds a batch of rows of one table, and
+// the last line counts the rows written, so truncated archives are
+// detected:
+//
+//	{"format":"gocraft-backup","version":1,"created":"2026-10-18T18:57:23Z"}
+//	{"table":"blocks","rows":[{"ChunkX":0,...},...]}
+//	{"table":"users","rows":[...]}
+//	{"end":true,"counts":{"blocks":12345,"chunks":42,"cameras":1,"users":7}}
+//
+// Archives contain password hashes, keep them private.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/store"
+	"gorm.io/gorm"
+)
+
+// Format and Version identify the archive format.
+const (
+	Format  = "gocraft-backup"
+	Version = 1
+)
+
+// batchSize is the number of rows per line and per INSERT on restore.
+const batchSize = 500
+
+// Tables are the tables in an archive, in the order they are written.
+var Tables = []string{"blocks", "chunks", "cameras", "users"}
+
+type header struct {
+	Format  string    `json:"format"`
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+}
+
+// line is a batch of rows or, with End set, the trailer.
+type line struct {
+	Table  string           `json:"table,omitempty"`
+	Rows   json.RawMessage  `json:"rows,omitempty"`
+	End    bool             `json:"end,omitempty"`
+	Counts map[string]int64 `json:"counts,omitempty"`
+}
+
+// Snapshot writes every table of s to w. All tables are read in one read
+// only transaction, so the snapshot is consistent while players keep
+// building. It returns the number of rows per table.
+func Snapshot(ctx context.Context, s *store.Store, w io.Writer) (map[string]int64, error) {
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	enc := json.NewEncoder(bw)
+	if err := enc.Encode(header{Format: Format, Version: Version, Created: time.Now().UTC()}); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(Tables))
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range Tables {
+			n, err := dumpTable(tx, table, enc)
+			if err != nil {
+				return fmt.Errorf("backup: dumping %s: %w", table, err)
+			}
+			counts[table] = n
+		}
+		return nil
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(line{End: true, Counts: counts}); err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func dumpTable(tx *gorm.DB, table string, enc *json.Encoder) (int64, error) {
+	switch table {
+	case "blocks":
+		return dump[store.Block](tx, table, enc)
+	case "chunks":
+		return dump[store.Chunk](tx, table, enc)
+	case "cameras":
+		return dump[store.Camera](tx, table, enc)
+	case "users":
+		return dump[store.User](tx, table, enc)
+	}
+	return 0, fmt.Errorf("unknown table %q", table)
+}
+
+// dump streams the rows of table in batches. The tables have no primary
+// key to page by, so it reads one cursor instead.
+func dump[T any](tx *gorm.DB, table string, enc *json.Encoder) (int64, error) {
+	rows, err := tx.Model(new(T)).Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var n int64
+	batch := make([]T, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		data, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		n += int64(len(batch))
+		batch = batch[:0]
+		return enc.Encode(line{Table: table, Rows: data})
+	}
+	for rows.Next() {
+		var row T
+		if err := tx.ScanRows(rows, &row); err != nil {
+			return n, err
+		}
+		if batch = append(batch, row); len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+	return n, flush()
+}
+
+// ErrNotEmpty is returned by Restore when the store already holds a world
+// or users.
+var ErrNotEmpty = errors.New("backup: the store is not empty, restore into a new database")
+
+// Restore reads an archive written by Snapshot into s, which must not
+// hold any blocks, chunks or users. The default camera is replaced. It
+// restores all of the archive or, on any error, nothing.
+func Restore(ctx context.Context, s *store.Store, r io.Reader) (map[string]int64, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("backup: %v", err)
+	}
+	defer zr.Close()
+	dec := json.NewDecoder(bufio.NewReader(zr))
+
+	var h header
+	if err := dec.Decode(&h); err != nil {
+		return nil, fmt.Errorf("backup: reading header: %v", err)
+	}
+	if h.Format != Format {
+		return nil, fmt.Errorf("backup: format %q is not %s", h.Format, Format)
+	}
+	if h.Version != Version {
+		return nil, fmt.Errorf("backup: version %d is not supported", h.Version)
+	}
+
+	counts := make(map[string]int64, len(Tables))
+	for _, table := range Tables {
+		counts[table] = 0
+	}
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&store.Block{}, &store.Chunk{}, &store.User{}} {
+			var n int64
+			if err := tx.Model(model).Count(&n).Error; err != nil {
+				return err
+			}
+			if n > 0 {
+				return ErrNotEmpty
+			}
+		}
+		if err := tx.Where("1 = 1").Delete(&store.Camera{}).Error; err != nil {
+			return err
+		}
+
+		for {
+			var l line
+			if err := dec.Decode(&l); err != nil {
+				if errors.Is(err, io.EOF) {
+					err = io.ErrUnexpectedEOF
+				}
+				return fmt.Errorf("backup: archive is truncated or corrupt: %v", err)
+			}
+			if l.End {
+				for _, table := range Tables {
+					if l.Counts[table] != counts[table] {
+						return fmt.Errorf("backup: archive lists %d %s, found %d", l.Counts[table], table, counts[table])
+					}
+				}
+				return nil
+			}
+			n, err := restoreRows(tx, l)
+			if err != nil {
+				return fmt.Errorf("backup: restoring %s: %w", l.Table, err)
+			}
+			counts[l.Table] += n
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func restoreRows(tx *gorm.DB, l line) (int64, error) {
+	switch l.Table {
+	case "blocks":
+		return restore[store.Block](tx, l.Rows)
+	case "chunks":
+		return restore[store.Chunk](tx, l.Rows)
+	case "cameras":
+		return restore[store.Camera](tx, l.Rows)
+	case "users":
+		return restore[store.User](tx, l.Rows)
+	}
+	return 0, fmt.Errorf("unknown table %q", l.Table)
+}
+
+func restore[T any](tx *gorm.DB, data json.RawMessage) (int64, error) {
+	var rows []T
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if err := tx.CreateInBatches(rows, batchSize).Error; err != nil {
+		return 0, err
+	}
+	return int64(len(rows)), nil
+}
@@ -0,0 +1,85 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/backup"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T) *store.Store {
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "gocraft.db")})
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	src := newStore(t)
+	blocks := make(map[store.Vec3]int)
+	for i := int32(0); i < 1200; i++ {
+		blocks[store.Vec3{X: i, Y: i % 7, Z: -i}] = int(i%60) + 1
+	}
+	_, err := src.UpdateBlocks(store.DefaultWorld, blocks, "v1")
+	require.NoError(t, err)
+	_, err = src.CreateUser(ctx, "alex", "secret", "alex@example.com")
+	require.NoError(t, err)
+	require.NoError(t, src.UpdateCamera(1, 2, 3, 4, 5))
+
+	var buf bytes.Buffer
+	counts, err := backup.Snapshot(ctx, src, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1200), counts["blocks"])
+	assert.Equal(t, int64(1), counts["users"])
+
+	// a truncated archive restores nothing
+	dst := newStore(t)
+	_, err = backup.Restore(ctx, dst, bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	assert.Error(t, err)
+	user, err := dst.GetUser(ctx, "alex")
+	require.NoError(t, err)
+	assert.Nil(t, user)
+
+	restored, err := backup.Restore(ctx, dst, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, counts, restored)
+	w, err := dst.GetBlock(store.DefaultWorld, store.Vec3{X: 100, Y: 100 % 7, Z: -100})
+	require.NoError(t, err)
+	assert.Equal(t, 100%60+1, w)
+	assert.Equal(t, "v1", dst.GetChunkVersion(store.DefaultWorld, store.Vec3{X: 1, Z: -2}))
+	user, err = dst.GetUser(ctx, "alex")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "alex@example.com", user.Email)
+	x, _, _, _, ry := dst.GetCamera()
+	assert.Equal(t, float32(1), x)
+	assert.Equal(t, float32(5), ry)
+
+	_, err = backup.Restore(ctx, dst, bytes.NewReader(buf.Bytes()))
+	assert.ErrorIs(t, err, backup.ErrNotEmpty)
+}
+
+func TestManagerRetention(t *testing.T) {
+	dir := t.TempDir()
+	m := backup.NewManager(newStore(t), dir, 2)
+	for _, name := range []string{"gocraft-20260101-000000.backup.gz", "gocraft-20260102-000000.backup.gz", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	path, err := m.Snapshot(context.Background())
+	require.NoError(t, err)
+	infos, err := m.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, filepath.Base(path), infos[0].Name)
+	assert.Equal(t, "gocraft-20260102-000000.backup.gz", infos[1].Name)
+	assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+}
@@ -0,0 +1,185 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/store"
+)
+
+// File names are the prefix, the UTC time of the snapshot and the suffix,
+// so they sort by age.
+const (
+	filePrefix = "gocraft-"
+	fileSuffix = ".backup.gz"
+	timeLayout = "20060102-150405"
+)
+
+// Manager writes snapshots into a directory, keeping the newest ones.
+type Manager struct {
+	store *store.Store
+	dir   string
+	keep  int
+
+	mu sync.Mutex // one snapshot at a time
+	// flush writes changes held in memory to the store before a snapshot.
+	flush func() error
+}
+
+// Info describes a snapshot file.
+type Info struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	Created time.Time `json:"created"`
+}
+
+// NewManager creates a manager keeping keep snapshots in dir.
+func NewManager(s *store.Store, dir string, keep int) *Manager {
+	return &Manager{store: s, dir: dir, keep: keep}
+}
+
+// SetFlush sets a function writing changes held in memory to the store,
+// e.g. those of a chunk cache. Snapshot calls it first.
+func (m *Manager) SetFlush(flush func() error) {
+	m.flush = flush
+}
+
+// Snapshot writes a snapshot into the directory and removes the oldest
+// beyond the retention. It returns the file written.
+func (m *Manager) Snapshot(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return "", fmt.Errorf("backup: %v", err)
+	}
+	if m.flush != nil {
+		if err := m.flush(); err != nil {
+			return "", fmt.Errorf("backup: writing pending changes: %v", err)
+		}
+	}
+	start := time.Now()
+	path := filepath.Join(m.dir, filePrefix+start.UTC().Format(timeLayout)+fileSuffix)
+
+	// 先写临时文件，中断的快照不会被当作备份
+	tmp, err := os.CreateTemp(m.dir, ".snapshot-*")
+	if err != nil {
+		return "", fmt.Errorf("backup: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	counts, err := Snapshot(ctx, m.store, tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("backup: %v", err)
+	}
+	slog.InfoContext(ctx, "snapshot written", "file", path, "rows", counts, "duration", time.Since(start))
+
+	if err := m.prune(ctx); err != nil {
+		slog.WarnContext(ctx, "removing old snapshots failed", "error", err)
+	}
+	return path, nil
+}
+
+// prune removes the oldest snapshots beyond the retention.
+func (m *Manager) prune(ctx context.Context) error {
+	infos, err := m.List()
+	if err != nil {
+		return err
+	}
+	for i := m.keep; i < len(infos); i++ {
+		if err := os.Remove(filepath.Join(m.dir, infos[i].Name)); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "old snapshot removed", "file", infos[i].Name)
+	}
+	return nil
+}
+
+// List returns the snapshots in the directory, newest first.
+func (m *Manager) List() ([]Info, error) {
+	entries, err := os.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var infos []Info
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		created, err := time.Parse(timeLayout, strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileSuffix))
+		if err != nil {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, Info{Name: name, Size: fi.Size(), Created: created})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name > infos[j].Name })
+	return infos, nil
+}
+
+// Run writes a snapshot every interval until ctx is done.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.Snapshot(ctx); err != nil {
+				slog.ErrorContext(ctx, "scheduled snapshot failed", "error", err)
+			}
+		}
+	}
+}
+
+// RegisterRoutes registers the admin routes listing and taking snapshots,
+// auth restricts them to admins.
+func (m *Manager) RegisterRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	r.GET("/admin/backups", auth, m.httpList)
+	r.POST("/admin/backups", auth, m.httpSnapshot)
+}
+
+func (m *Manager) httpList(c *gin.Context) {
+	infos, err := m.List()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "listing snapshots failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "listing snapshots failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"backups": infos})
+}
+
+func (m *Manager) httpSnapshot(c *gin.Context) {
+	path, err := m.Snapshot(c.Request.Context())
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "snapshot failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "snapshot failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"name": filepath.Base(path)})
+}
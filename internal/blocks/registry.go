@@ -0,0 +1,161 @@
+// Package blocks holds the registry of block types the server accepts.
+package blocks
+
+import (
+	"bytes"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Air is the empty block, placing it removes a block.
+const Air int32 = 0
+
+//go:embed default.yaml
+var defaultBlocks []byte
+
+// Type describes a block type.
+type Type struct {
+	ID          int32   `yaml:"id" toml:"id"`
+	Name        string  `yaml:"name" toml:"name"`
+	Solid       bool    `yaml:"solid" toml:"solid"`
+	Transparent bool    `yaml:"transparent" toml:"transparent"`
+	Light       int32   `yaml:"light" toml:"light"`
+	Hardness    float32 `yaml:"hardness" toml:"hardness"`
+	Placeable   bool    `yaml:"placeable" toml:"placeable"`
+}
+
+// file is the layout of a registry file.
+type file struct {
+	Blocks []Type `yaml:"blocks" toml:"blocks"`
+}
+
+// Registry is an immutable set of block types.
+type Registry struct {
+	types   []Type
+	byID    map[int32]Type
+	version string
+}
+
+// New checks types and builds a registry of them.
+func New(types []Type) (*Registry, error) {
+	r := &Registry{
+		types: append([]Type(nil), types...),
+		byID:  make(map[int32]Type, len(types)),
+	}
+	sort.Slice(r.types, func(i, j int) bool { return r.types[i].ID < r.types[j].ID })
+
+	var errs []error
+	names := make(map[string]bool, len(types))
+	for _, t := range r.types {
+		switch {
+		case t.ID <= Air:
+			errs = append(errs, fmt.Errorf("block %q: id %d must be positive, 0 is air", t.Name, t.ID))
+		case t.Name == "":
+			errs = append(errs, fmt.Errorf("block %d: name is required", t.ID))
+		case t.Light < 0 || t.Light > 15:
+			errs = append(errs, fmt.Errorf("block %q: light %d is out of range 0..15", t.Name, t.Light))
+		case t.Hardness < 0:
+			errs = append(errs, fmt.Errorf("block %q: hardness must not be negative", t.Name))
+		}
+		if _, ok := r.byID[t.ID]; ok {
+			errs = append(errs, fmt.Errorf("block %q: id %d is already used", t.Name, t.ID))
+		}
+		if names[t.Name] {
+			errs = append(errs, fmt.Errorf("block %d: name %q is already used", t.ID, t.Name))
+		}
+		r.byID[t.ID] = t
+		names[t.Name] = true
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid block registry:\n%w", errors.Join(errs...))
+	}
+
+	// the version changes with any definition, clients cache by it
+	h := sha256.New()
+	for _, t := range r.types {
+		fmt.Fprintf(h, "%+v\n", t)
+	}
+	r.version = hex.EncodeToString(h.Sum(nil))[:16]
+	return r, nil
+}
+
+// Default returns the block types of the gocraft client.
+func Default() *Registry {
+	r, err := parse(defaultBlocks, ".yaml")
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Load reads a registry from a .yaml, .yml or .toml file with a top
+// level blocks list.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("blocks: %v", err)
+	}
+	r, err := parse(data, filepath.Ext(path))
+	if err != nil {
+		return nil, fmt.Errorf("blocks: %s: %w", path, err)
+	}
+	return r, nil
+}
+
+func parse(data []byte, ext string) (*Registry, error) {
+	var f file
+	var err error
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&f)
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&f)
+	default:
+		return nil, fmt.Errorf("unknown format %q, use .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return New(f.Blocks)
+}
+
+// Lookup returns the type with id.
+func (r *Registry) Lookup(id int32) (Type, bool) {
+	t, ok := r.byID[id]
+	return t, ok
+}
+
+// Solid reports whether players collide with blocks of type id. Unknown
+// types, e.g. stored before they were removed from the registry, are
+// solid.
+func (r *Registry) Solid(id int32) bool {
+	if id == Air {
+		return false
+	}
+	t, ok := r.byID[id]
+	return !ok || t.Solid
+}
+
+// Types returns the types ordered by ID.
+func (r *Registry) Types() []Type {
+	return append([]Type(nil), r.types...)
+}
+
+// Version identifies the registry contents.
+func (r *Registry) Version() string {
+	return r.version
+}
@@ -0,0 +1,62 @@
+package blocks_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	r := blocks.Default()
+	assert.Len(t, r.Types(), 55)
+
+	glass, ok := r.Lookup(10)
+	require.True(t, ok)
+	assert.Equal(t, "glass", glass.Name)
+	assert.True(t, glass.Solid && glass.Transparent && glass.Placeable)
+
+	cloud, _ := r.Lookup(16)
+	assert.False(t, cloud.Placeable)
+
+	assert.False(t, r.Solid(blocks.Air))
+	assert.False(t, r.Solid(17)) // tall grass
+	assert.True(t, r.Solid(1000), "unknown types are solid")
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocks.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[blocks]]
+id = 1
+name = "grass"
+solid = true
+placeable = true
+
+[[blocks]]
+id = 100
+name = "lava"
+light = 15
+`), 0o644))
+	r, err := blocks.Load(path)
+	require.NoError(t, err)
+	lava, ok := r.Lookup(100)
+	require.True(t, ok)
+	assert.Equal(t, int32(15), lava.Light)
+	assert.NotEqual(t, blocks.Default().Version(), r.Version())
+
+	_, err = blocks.New([]blocks.Type{
+		{ID: 1, Name: "grass"},
+		{ID: 1, Name: "dirt"},
+		{ID: 0, Name: "void"},
+		{ID: 2, Name: "grass", Light: 20},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "id 1 is already used")
+	assert.Contains(t, err.Error(), "0 is air")
+	assert.Contains(t, err.Error(), `name "grass" is already used`)
+	assert.Contains(t, err.Error(), "out of range")
+}
@@ -0,0 +1,64 @@
+// Package chat holds the moderation helpers of the chat service.
+package chat
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Filter masks banned words in chat messages. A nil *Filter passes
+// everything.
+type Filter struct {
+	re *regexp.Regexp
+}
+
+// NewFilter returns a filter for words, matched ignoring case. Words are
+// matched whole where they start or end with a letter or digit of the
+// Latin alphabet; words of scripts written without spaces, like Chinese,
+// match anywhere. It returns nil for an empty list.
+func NewFilter(words []string) *Filter {
+	patterns := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			patterns = append(patterns, wordPattern(w))
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	return &Filter{re: regexp.MustCompile(`(?i)(?:` + strings.Join(patterns, "|") + `)`)}
+}
+
+// wordPattern anchors w at word boundaries where \b can see them, which
+// only knows ASCII word characters.
+func wordPattern(w string) string {
+	p := regexp.QuoteMeta(w)
+	first, _ := utf8.DecodeRuneInString(w)
+	last, _ := utf8.DecodeLastRuneInString(w)
+	if isASCIIWord(first) {
+		p = `\b` + p
+	}
+	if isASCIIWord(last) {
+		p += `\b`
+	}
+	return p
+}
+
+func isASCIIWord(r rune) bool {
+	return r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// Clean replaces every banned word of text by asterisks and reports
+// whether it found any.
+func (f *Filter) Clean(text string) (string, bool) {
+	if f == nil {
+		return text, false
+	}
+	found := false
+	cleaned := f.re.ReplaceAllStringFunc(text, func(word string) string {
+		found = true
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+	return cleaned, found
+}
@@ -0,0 +1,31 @@
+package chat_test
+
+import (
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/chat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	f := chat.NewFilter([]string{"darn", " heck ", "", "a.b", "笨蛋"})
+
+	text, found := f.Clean("Darn it, what the HECK")
+	assert.True(t, found)
+	assert.Equal(t, "**** it, what the ****", text)
+
+	// only whole words, and the words are not patterns
+	text, found = f.Clean("darnation aXb")
+	assert.False(t, found)
+	assert.Equal(t, "darnation aXb", text)
+
+	text, found = f.Clean("你这个笨蛋")
+	assert.True(t, found)
+	assert.Equal(t, "你这个**", text)
+
+	none := chat.NewFilter(nil)
+	assert.Nil(t, none)
+	text, found = none.Clean("darn")
+	assert.False(t, found)
+	assert.Equal(t, "darn", text)
+}
@@ -0,0 +1,460 @@
+// Package chunkcache keeps recently used chunks in memory in front of the
+// store. Reads are served from memory and writes change the cached
+// chunks; Flush writes the changed chunks to the store in batches.
+//
+// Cache has the block methods of store.Store, so it can stand in for it.
+// Everything written through the cache must be read through it too until
+// it is flushed.
+package chunkcache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/metrics"
+	"github.com/perlinson/gocraft-server/internal/store"
+)
+
+// ErrClosed is returned by writes after Close.
+var ErrClosed = errors.New("chunkcache: closed")
+
+// flushBatch is the number of chunks written in one transaction.
+const flushBatch = 64
+
+type key struct {
+	world string
+	p, q  int32
+}
+
+type block struct {
+	w       int
+	version string
+}
+
+type chunk struct {
+	key     key
+	elem    *list.Element
+	version string
+	blocks  map[store.Vec3]block
+	// dirty are the blocks changed since the chunk was last written, w is
+	// store.Unset for deleted blocks. A dirty chunk may have no changed
+	// blocks when only its version changed.
+	dirty    map[store.Vec3]block
+	pins     int
+	flushing bool
+}
+
+// Cache is a bounded LRU cache of chunks with write-behind.
+type Cache struct {
+	store     *store.Store
+	maxChunks int
+	maxBlocks int
+
+	mu      sync.Mutex
+	lru     *list.List // front is the most recently used
+	chunks  map[key]*chunk
+	dirty   map[key]*chunk
+	loading map[key]chan struct{}
+	nblocks int
+	closed  bool
+
+	// flushMu orders flushes against RangeRegion, which reads the store
+	// and must not see chunks flushed and evicted halfway.
+	flushMu sync.RWMutex
+	// full wakes Run when dirty chunks keep the cache over its bounds.
+	full chan struct{}
+}
+
+// New creates a cache of at most maxChunks chunks and maxBlocks blocks in
+// front of s.
+func New(s *store.Store, maxChunks, maxBlocks int) *Cache {
+	return &Cache{
+		store:     s,
+		maxChunks: maxChunks,
+		maxBlocks: maxBlocks,
+		lru:       list.New(),
+		chunks:    make(map[key]*chunk),
+		dirty:     make(map[key]*chunk),
+		loading:   make(map[key]chan struct{}),
+		full:      make(chan struct{}, 1),
+	}
+}
+
+func worldName(world string) string {
+	if world == "" {
+		return store.DefaultWorld
+	}
+	return world
+}
+
+func chunkKey(world string, id store.Vec3) key {
+	return key{worldName(world), id.X, id.Z}
+}
+
+// Len returns the number of cached chunks and of those not yet written.
+func (c *Cache) Len() (cached, dirty int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.chunks), len(c.dirty)
+}
+
+// load returns chunk k, reading it from the store on a miss. It must be
+// called with c.mu held and releases it while reading; concurrent loads
+// of one chunk read the store once.
+func (c *Cache) load(k key) (*chunk, error) {
+	for {
+		if ch, ok := c.chunks[k]; ok {
+			c.lru.MoveToFront(ch.elem)
+			metrics.ChunkCacheLookups.WithLabelValues("hit").Inc()
+			return ch, nil
+		}
+		wait, ok := c.loading[k]
+		if !ok {
+			break
+		}
+		c.mu.Unlock()
+		<-wait
+		c.mu.Lock()
+	}
+	metrics.ChunkCacheLookups.WithLabelValues("miss").Inc()
+
+	done := make(chan struct{})
+	c.loading[k] = done
+	c.mu.Unlock()
+	version, rows, err := c.store.LoadChunk(k.world, store.Vec3{X: k.p, Z: k.q})
+	c.mu.Lock()
+	delete(c.loading, k)
+	close(done)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := &chunk{key: k, version: version, blocks: make(map[store.Vec3]block, len(rows))}
+	for _, b := range rows {
+		ch.blocks[store.Vec3{X: b.BlockX, Y: b.BlockY, Z: b.BlockZ}] = block{int(b.BlockType), b.Version}
+	}
+	ch.elem = c.lru.PushFront(ch)
+	c.chunks[k] = ch
+	c.nblocks += len(ch.blocks)
+	c.evict()
+	return ch, nil
+}
+
+// evict drops the least recently used chunks beyond the bounds. Changed,
+// pinned and new chunks stay; must be called with c.mu held.
+func (c *Cache) evict() {
+	e := c.lru.Back()
+	for (len(c.chunks) > c.maxChunks || c.nblocks > c.maxBlocks) && e != nil && e != c.lru.Front() {
+		ch := e.Value.(*chunk)
+		e = e.Prev()
+		if ch.pins > 0 || ch.flushing || ch.dirty != nil {
+			continue
+		}
+		c.lru.Remove(ch.elem)
+		delete(c.chunks, ch.key)
+		c.nblocks -= len(ch.blocks)
+	}
+	if len(c.chunks) > c.maxChunks || c.nblocks > c.maxBlocks {
+		select {
+		case c.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// pin loads the chunks ids of world and keeps them from being evicted
+// until unpin. It must be called with c.mu held.
+func (c *Cache) pin(world string, ids []store.Vec3) ([]*chunk, error) {
+	chunks := make([]*chunk, 0, len(ids))
+	for _, id := range ids {
+		ch, err := c.load(chunkKey(world, id))
+		if err != nil {
+			c.unpin(chunks)
+			return nil, err
+		}
+		ch.pins++
+		chunks = append(chunks, ch)
+	}
+	return chunks, nil
+}
+
+func (c *Cache) unpin(chunks []*chunk) {
+	for _, ch := range chunks {
+		ch.pins--
+	}
+	c.evict()
+}
+
+// set changes block id of ch, w store.Unset deletes it. It must be called
+// with c.mu held.
+func (c *Cache) set(ch *chunk, id store.Vec3, w int, version string) {
+	_, had := ch.blocks[id]
+	if w == store.Unset {
+		delete(ch.blocks, id)
+	} else {
+		ch.blocks[id] = block{w, version}
+	}
+	if _, has := ch.blocks[id]; has != had {
+		if has {
+			c.nblocks++
+		} else {
+			c.nblocks--
+		}
+	}
+	c.markDirty(ch)
+	ch.dirty[id] = block{w, version}
+}
+
+func (c *Cache) markDirty(ch *chunk) {
+	if ch.dirty == nil {
+		ch.dirty = make(map[store.Vec3]block)
+	}
+	c.dirty[ch.key] = ch
+}
+
+// UpdateBlock is store.Store.UpdateBlock in memory. The condition is
+// checked against the cached chunk.
+func (c *Cache) UpdateBlock(world string, id store.Vec3, w int, version string, cond store.Condition) ([]store.Vec3, error) {
+	world = worldName(world)
+	affected := id.AffectedChunks()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	chunks, err := c.pin(world, affected)
+	if err != nil {
+		return nil, err
+	}
+	defer c.unpin(chunks)
+
+	// chunks[0] 是方块所在的区块
+	ch := chunks[0]
+	if cond.ChunkVersion != nil || cond.BlockVersion != nil {
+		b := ch.blocks[id]
+		if (cond.ChunkVersion != nil && *cond.ChunkVersion != ch.version) ||
+			(cond.BlockVersion != nil && *cond.BlockVersion != b.version) {
+			return nil, &store.ConflictError{ChunkVersion: ch.version, Block: store.Block{
+				World: world, ChunkX: ch.key.p, ChunkZ: ch.key.q,
+				BlockX: id.X, BlockY: id.Y, BlockZ: id.Z, BlockType: int32(b.w), Version: b.version,
+			}}
+		}
+	}
+
+	c.set(ch, id, w, version)
+	for _, ch := range chunks {
+		ch.version = version
+		c.markDirty(ch)
+	}
+	return affected, nil
+}
+
+// UpdateBlocks is store.Store.UpdateBlocks in memory.
+func (c *Cache) UpdateBlocks(world string, blocks map[store.Vec3]int, version string) ([]store.Vec3, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	world = worldName(world)
+	affected := store.AffectedChunksOf(blocks)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	chunks, err := c.pin(world, affected)
+	if err != nil {
+		return nil, err
+	}
+	defer c.unpin(chunks)
+
+	byKey := make(map[key]*chunk, len(chunks))
+	for _, ch := range chunks {
+		byKey[ch.key] = ch
+		ch.version = version
+		c.markDirty(ch)
+	}
+	for id, w := range blocks {
+		c.set(byKey[chunkKey(world, id.Chunkid())], id, w, version)
+	}
+	return affected, nil
+}
+
+// GetBlock is store.Store.GetBlock, loading the block's chunk.
+func (c *Cache) GetBlock(world string, id store.Vec3) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch, err := c.load(chunkKey(world, id.Chunkid()))
+	if err != nil {
+		return 0, err
+	}
+	return ch.blocks[id].w, nil
+}
+
+// GetChunkVersion is store.Store.GetChunkVersion, loading the chunk.
+func (c *Cache) GetChunkVersion(world string, id store.Vec3) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch, err := c.load(chunkKey(world, id))
+	if err != nil {
+		slog.Error("error getting chunk version", "world", world, "chunk", id, "error", err)
+		return ""
+	}
+	return ch.version
+}
+
+// RangeBlocks is store.Store.RangeBlocks, loading the chunk. f must not
+// call the cache.
+func (c *Cache) RangeBlocks(world string, id store.Vec3, f func(bid store.Vec3, w int, version string)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch, err := c.load(chunkKey(world, id))
+	if err != nil {
+		return err
+	}
+	for bid, b := range ch.blocks {
+		f(bid, b.w, b.version)
+	}
+	return nil
+}
+
+// RangeRegion is store.Store.RangeRegion. Cached chunks are read from
+// memory, the others from the store without caching them, so large
+// regions don't flush the cache. f must not call the cache.
+func (c *Cache) RangeRegion(world string, min, max store.Vec3, f func(bid store.Vec3, w int)) error {
+	world = worldName(world)
+	c.flushMu.RLock()
+	defer c.flushMu.RUnlock()
+
+	// 先读存储再读内存：读存储期间新加载或修改的区块以内存为准
+	stored := make(map[store.Vec3]int)
+	err := c.store.RangeRegion(world, min, max, func(bid store.Vec3, w int) {
+		stored[bid] = w
+	})
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	inside := func(id store.Vec3) bool {
+		return id.X >= min.X && id.X <= max.X && id.Y >= min.Y && id.Y <= max.Y && id.Z >= min.Z && id.Z <= max.Z
+	}
+	// 遍历缓存的区块而不是区域中的所有区块，区域再大也只需要缓存大小的时间
+	lo, hi := min.Chunkid(), max.Chunkid()
+	cached := make(map[key]bool)
+	for k, ch := range c.chunks {
+		if k.world != world || k.p < lo.X || k.p > hi.X || k.q < lo.Z || k.q > hi.Z {
+			continue
+		}
+		cached[k] = true
+		for bid, b := range ch.blocks {
+			if inside(bid) {
+				f(bid, b.w)
+			}
+		}
+	}
+	for bid, w := range stored {
+		if !cached[chunkKey(world, bid.Chunkid())] {
+			f(bid, w)
+		}
+	}
+	return nil
+}
+
+// Flush writes the changed chunks to the store, flushBatch chunks per
+// transaction. Chunks of failed batches stay changed and are retried by
+// the next Flush.
+func (c *Cache) Flush() error {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	c.mu.Lock()
+	chunks := make([]*chunk, 0, len(c.dirty))
+	writes := make([]store.ChunkWrite, 0, len(c.dirty))
+	for _, ch := range c.dirty {
+		w := store.ChunkWrite{World: ch.key.world, ID: store.Vec3{X: ch.key.p, Z: ch.key.q}, Version: ch.version}
+		for id, b := range ch.dirty {
+			w.Blocks = append(w.Blocks, store.Block{
+				ChunkX: ch.key.p, ChunkZ: ch.key.q,
+				BlockX: id.X, BlockY: id.Y, BlockZ: id.Z, BlockType: int32(b.w), Version: b.version,
+			})
+		}
+		ch.dirty = nil
+		ch.flushing = true
+		chunks = append(chunks, ch)
+		writes = append(writes, w)
+	}
+	c.dirty = make(map[key]*chunk)
+	c.mu.Unlock()
+
+	var errs []error
+	for start := 0; start < len(writes); start += flushBatch {
+		end := min(start+flushBatch, len(writes))
+		err := c.store.WriteChunks(writes[start:end])
+
+		c.mu.Lock()
+		for i := start; i < end; i++ {
+			ch := chunks[i]
+			ch.flushing = false
+			if err == nil {
+				continue
+			}
+			// 写入失败的修改放回去，之后的修改优先
+			c.markDirty(ch)
+			for _, b := range writes[i].Blocks {
+				id := store.Vec3{X: b.BlockX, Y: b.BlockY, Z: b.BlockZ}
+				if _, ok := ch.dirty[id]; !ok {
+					ch.dirty[id] = block{int(b.BlockType), b.Version}
+				}
+			}
+		}
+		c.evict()
+		c.mu.Unlock()
+
+		if err != nil {
+			metrics.ChunkCacheFlushes.WithLabelValues("error").Inc()
+			errs = append(errs, err)
+		} else {
+			metrics.ChunkCacheFlushes.WithLabelValues("ok").Inc()
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if len(writes) > 0 {
+		slog.Debug("chunk cache flushed", "chunks", len(writes))
+	}
+	return nil
+}
+
+// Run flushes every interval, and early when changed chunks fill the
+// cache, until ctx is done.
+func (c *Cache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.full:
+		}
+		if err := c.Flush(); err != nil {
+			slog.ErrorContext(ctx, "flushing chunk cache failed", "error", err)
+		}
+	}
+}
+
+// Close rejects further writes and flushes the changed chunks.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return c.Flush()
+}
@@ -0,0 +1,82 @@
+package chunkcache_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/chunkcache"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBehind(t *testing.T) {
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "gocraft.db")})
+	require.NoError(t, err)
+	defer s.Close()
+	_, err = s.UpdateBlocks("", map[store.Vec3]int{{X: 1, Y: 1, Z: 1}: 3, {X: 2, Y: 1, Z: 1}: 4}, "v0")
+	require.NoError(t, err)
+
+	c := chunkcache.New(s, 2, 1000)
+	chunks, err := c.UpdateBlocks("", map[store.Vec3]int{{X: 1, Y: 1, Z: 1}: 5, {X: 2, Y: 1, Z: 1}: store.Unset, {X: 31, Y: 1, Z: 1}: 6}, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, []store.Vec3{{}, {X: 1}}, chunks)
+
+	// changes stay in memory until flushed
+	w, err := c.GetBlock("", store.Vec3{X: 1, Y: 1, Z: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 5, w)
+	assert.Equal(t, "v1", c.GetChunkVersion(store.DefaultWorld, store.Vec3{X: 1}))
+	w, err = s.GetBlock("", store.Vec3{X: 1, Y: 1, Z: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, w)
+	n := 0
+	require.NoError(t, c.RangeRegion("", store.Vec3{}, store.Vec3{X: 40, Y: 2, Z: 2}, func(store.Vec3, int) { n++ }))
+	assert.Equal(t, 2, n)
+	// huge regions only visit the cached chunks
+	n = 0
+	require.NoError(t, c.RangeRegion("", store.Vec3{X: -1 << 29, Y: -1 << 29, Z: -1 << 29}, store.Vec3{X: 1 << 29, Y: 1 << 29, Z: 1 << 29}, func(store.Vec3, int) { n++ }))
+	assert.Equal(t, 2, n)
+
+	// conditions are checked against the cached version
+	stale := "v0"
+	_, err = c.UpdateBlock("", store.Vec3{X: 1, Y: 1, Z: 1}, 7, "v2", store.Condition{ChunkVersion: &stale})
+	var conflict *store.ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "v1", conflict.ChunkVersion)
+	assert.Equal(t, int32(5), conflict.Block.BlockType)
+
+	// dirty chunks are never evicted
+	for p := int32(2); p < 6; p++ {
+		c.GetChunkVersion("", store.Vec3{X: p})
+	}
+	cached, dirty := c.Len()
+	assert.Equal(t, 2, dirty)
+	assert.GreaterOrEqual(t, cached, 2)
+
+	require.NoError(t, c.Flush())
+	cached, dirty = c.Len()
+	assert.Equal(t, 0, dirty)
+	assert.LessOrEqual(t, cached, 2)
+	w, err = s.GetBlock("", store.Vec3{X: 1, Y: 1, Z: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 5, w)
+	assert.Equal(t, "v1", s.GetChunkVersion("", store.Vec3{X: 1}))
+	n = 0
+	require.NoError(t, s.RangeBlocks("", store.Vec3{}, func(_ store.Vec3, _ int, version string) {
+		assert.Equal(t, "v1", version)
+		n++
+	}))
+	assert.Equal(t, 2, n, "the unset block is deleted")
+
+	// Close flushes and rejects later writes
+	_, err = c.UpdateBlock("", store.Vec3{X: 1, Y: 1, Z: 1}, 8, "v3", store.Condition{})
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+	w, err = s.GetBlock("", store.Vec3{X: 1, Y: 1, Z: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 8, w)
+	_, err = c.UpdateBlock("", store.Vec3{X: 1, Y: 1, Z: 1}, 9, "v4", store.Condition{})
+	assert.ErrorIs(t, err, chunkcache.ErrClosed)
+}
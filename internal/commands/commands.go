@@ -0,0 +1,374 @@
+// Package commands parses and runs slash commands like "/tp 0 64 0 bob".
+// Commands come from chat, the command RPC or the server console; the
+// services and plugins add theirs with Registry.Register.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sender is who runs a command.
+type Sender struct {
+	// Name is the username, "console" for the server console.
+	Name string
+	// Role is the user role, its permission nodes are configured.
+	Role string
+	// Player is the player id of the sender, empty if it has none, e.g.
+	// on the console. Commands acting on "me" act on it.
+	Player string
+	// Console senders have every permission.
+	Console bool
+}
+
+// ConsoleSender runs commands typed on the server console.
+var ConsoleSender = Sender{Name: "console", Console: true}
+
+// Command is a slash command.
+type Command struct {
+	// Name is typed after the slash, lowercase without spaces.
+	Name    string
+	Aliases []string
+	// Permission is the node senders need, "command.<name>" if empty.
+	Permission string
+	// Help is a one-line description.
+	Help string
+	// Args are parsed in order. Optional arguments follow the required
+	// ones and a Text argument comes last.
+	Args []Arg
+	// Run executes the command and returns the output for the sender.
+	// Errors should be gRPC status errors, their message is shown.
+	Run func(ctx context.Context, sender Sender, args Args) (string, error)
+}
+
+// Arg is an argument of a command.
+type Arg struct {
+	Name     string
+	Type     Type
+	Optional bool
+}
+
+// Usage returns the syntax of the command, e.g. "/tp <x> <y> <z> [player]".
+func (c *Command) Usage() string {
+	var b strings.Builder
+	b.WriteString("/" + c.Name)
+	for _, a := range c.Args {
+		b.WriteString(" " + a.usage())
+	}
+	return b.String()
+}
+
+func (a Arg) usage() string {
+	if a.Optional {
+		return "[" + a.Name + "]"
+	}
+	return "<" + a.Name + ">"
+}
+
+// Node returns the permission node of the command.
+func (c *Command) Node() string {
+	if c.Permission != "" {
+		return c.Permission
+	}
+	return "command." + c.Name
+}
+
+// Args are the parsed arguments of a command by name. Optional arguments
+// that were not given are missing.
+type Args map[string]interface{}
+
+// Has reports whether the argument name was given.
+func (a Args) Has(name string) bool {
+	_, ok := a[name]
+	return ok
+}
+
+// String returns a String, Text or Enum argument, "" if missing.
+func (a Args) String(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+// Int returns an Int argument, 0 if missing.
+func (a Args) Int(name string) int64 {
+	n, _ := a[name].(int64)
+	return n
+}
+
+// Float returns a Float argument, 0 if missing.
+func (a Args) Float(name string) float64 {
+	f, _ := a[name].(float64)
+	return f
+}
+
+// Duration returns a Duration argument, 0 if missing.
+func (a Args) Duration(name string) time.Duration {
+	d, _ := a[name].(time.Duration)
+	return d
+}
+
+// Registry holds the commands and the permissions of the roles. It is
+// safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	commands map[string]*Command
+	byAlias  map[string]*Command
+	grants   map[string][]string
+}
+
+// NewRegistry returns a registry holding /help. permissions lists the
+// permission nodes granted to each role, e.g. "command.tp"; "command.*"
+// grants every node under command and "*" every node.
+func NewRegistry(permissions map[string][]string) *Registry {
+	r := &Registry{
+		commands: make(map[string]*Command),
+		byAlias:  make(map[string]*Command),
+		grants:   permissions,
+	}
+	r.Register(&Command{
+		Name:    "help",
+		Aliases: []string{"?"},
+		Help:    "lists the commands or explains one",
+		Args:    []Arg{{Name: "command", Type: commandType{r}, Optional: true}},
+		Run:     r.help,
+	})
+	return r
+}
+
+// Register adds a command. It fails if the command is malformed or its
+// name or an alias is taken.
+func (r *Registry) Register(c *Command) error {
+	if c.Name == "" || strings.ToLower(c.Name) != c.Name || strings.ContainsAny(c.Name, " /") {
+		return fmt.Errorf("commands: invalid command name %q", c.Name)
+	}
+	if c.Run == nil {
+		return fmt.Errorf("commands: /%s has no Run function", c.Name)
+	}
+	optional := false
+	for i, a := range c.Args {
+		if a.Type == nil {
+			return fmt.Errorf("commands: /%s: argument %s has no type", c.Name, a.Name)
+		}
+		if _, ok := a.Type.(textType); ok && i != len(c.Args)-1 {
+			return fmt.Errorf("commands: /%s: text argument %s must be last", c.Name, a.Name)
+		}
+		if optional && !a.Optional {
+			return fmt.Errorf("commands: /%s: required argument %s follows an optional one", c.Name, a.Name)
+		}
+		optional = a.Optional
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := append([]string{c.Name}, c.Aliases...)
+	for _, name := range names {
+		if _, ok := r.byAlias[name]; ok {
+			return fmt.Errorf("commands: /%s is already registered", name)
+		}
+	}
+	r.commands[c.Name] = c
+	for _, name := range names {
+		r.byAlias[name] = c
+	}
+	return nil
+}
+
+// Allowed reports whether sender holds the permission node.
+func (r *Registry) Allowed(sender Sender, node string) bool {
+	if sender.Console {
+		return true
+	}
+	for _, grant := range r.grants[sender.Role] {
+		if grant == "*" || grant == node ||
+			strings.HasSuffix(grant, ".*") && strings.HasPrefix(node, strings.TrimSuffix(grant, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Commands returns the commands sender may run, sorted by name.
+func (r *Registry) Commands(sender Sender) []*Command {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var cmds []*Command
+	for _, c := range r.commands {
+		if r.Allowed(sender, c.Node()) {
+			cmds = append(cmds, c)
+		}
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].Name < cmds[j].Name })
+	return cmds
+}
+
+// lookup returns the command called name or aliased so, if sender may
+// run it.
+func (r *Registry) lookup(sender Sender, name string) (*Command, error) {
+	r.mu.RLock()
+	c, ok := r.byAlias[strings.ToLower(name)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown command /%s, try /help", name)
+	}
+	if !r.Allowed(sender, c.Node()) {
+		return nil, status.Errorf(codes.PermissionDenied, "you may not use /%s", c.Name)
+	}
+	return c, nil
+}
+
+// Execute parses and runs a command line, with or without the leading
+// slash, and returns its output.
+func (r *Registry) Execute(ctx context.Context, sender Sender, line string) (string, error) {
+	words := split(line)
+	if len(words) == 0 {
+		return "", status.Error(codes.InvalidArgument, "empty command")
+	}
+	c, err := r.lookup(sender, words[0].text)
+	if err != nil {
+		return "", err
+	}
+	args, err := c.parse(ctx, line, words[1:])
+	if err != nil {
+		return "", err
+	}
+	return c.Run(ctx, sender, args)
+}
+
+// parse converts the words after the command name into its arguments.
+func (c *Command) parse(ctx context.Context, line string, words []word) (Args, error) {
+	args := make(Args, len(c.Args))
+	for i, a := range c.Args {
+		if i >= len(words) {
+			if a.Optional {
+				break
+			}
+			return nil, status.Errorf(codes.InvalidArgument, "missing %s, usage: %s", a.Name, c.Usage())
+		}
+		text := words[i].text
+		if _, ok := a.Type.(textType); ok {
+			text = strings.TrimSpace(line[words[i].start:])
+			words = words[:i+1]
+		}
+		v, err := a.Type.Parse(ctx, text)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v, usage: %s", a.Name, err, c.Usage())
+		}
+		args[a.Name] = v
+	}
+	if len(words) > len(c.Args) {
+		return nil, status.Errorf(codes.InvalidArgument, "too many arguments, usage: %s", c.Usage())
+	}
+	return args, nil
+}
+
+// Complete returns the completions of the last word of a partial command
+// line and a hint naming the argument being typed, like "<x:float>".
+func (r *Registry) Complete(ctx context.Context, sender Sender, line string) (suggestions []string, hint string) {
+	words := split(line)
+	if len(words) == 0 || strings.HasSuffix(line, " ") {
+		words = append(words, word{start: len(line)})
+	}
+	if len(words) == 1 {
+		prefix := strings.ToLower(words[0].text)
+		for _, c := range r.Commands(sender) {
+			for _, name := range append([]string{c.Name}, c.Aliases...) {
+				if strings.HasPrefix(name, prefix) {
+					suggestions = append(suggestions, name)
+				}
+			}
+		}
+		sort.Strings(suggestions)
+		return suggestions, "<command>"
+	}
+	c, err := r.lookup(sender, words[0].text)
+	if err != nil {
+		return nil, ""
+	}
+	i := len(words) - 2
+	if i >= len(c.Args) {
+		last := len(c.Args) - 1
+		if last < 0 {
+			return nil, ""
+		}
+		if _, ok := c.Args[last].Type.(textType); !ok {
+			return nil, ""
+		}
+		i = last
+	}
+	a := c.Args[i]
+	hint = fmt.Sprintf("<%s:%s>", a.Name, a.Type.Name())
+	if a.Optional {
+		hint = fmt.Sprintf("[%s:%s]", a.Name, a.Type.Name())
+	}
+	return a.Type.Complete(ctx, words[len(words)-1].text), hint
+}
+
+// help implements /help.
+func (r *Registry) help(ctx context.Context, sender Sender, args Args) (string, error) {
+	if name := args.String("command"); name != "" {
+		c, err := r.lookup(sender, name)
+		if err != nil {
+			return "", err
+		}
+		out := c.Usage()
+		if c.Help != "" {
+			out += " - " + c.Help
+		}
+		if len(c.Aliases) > 0 {
+			out += "\naliases: /" + strings.Join(c.Aliases, ", /")
+		}
+		return out, nil
+	}
+	var lines []string
+	for _, c := range r.Commands(sender) {
+		lines = append(lines, c.Usage()+" - "+c.Help)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// word is a word of a command line and where it starts.
+type word struct {
+	text  string
+	start int
+}
+
+// split splits a command line into words at spaces. Double quotes group
+// words, `/tp "big bob"` has one argument. A leading slash is dropped.
+func split(line string) []word {
+	var words []word
+	start := -1
+	quoted := false
+	var b strings.Builder
+	for i, r := range line {
+		switch {
+		case r == '/' && start < 0 && len(words) == 0:
+		case r == '"':
+			if start < 0 {
+				start = i
+			}
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if start >= 0 {
+				words = append(words, word{b.String(), start})
+				b.Reset()
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = i
+			}
+			b.WriteRune(r)
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{b.String(), start})
+	}
+	return words
+}
@@ -0,0 +1,90 @@
+package commands_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	r := commands.NewRegistry(map[string][]string{
+		"player": {"command.help"},
+		"admin":  {"command.*"},
+	})
+	players := commands.Enum{Kind: "player", Values: func(context.Context) []string { return []string{"bob", "bill", "alice"} }}
+	require.NoError(t, r.Register(&commands.Command{
+		Name:    "tp",
+		Aliases: []string{"teleport"},
+		Help:    "teleports a player",
+		Args: []commands.Arg{
+			{Name: "x", Type: commands.Float},
+			{Name: "y", Type: commands.Float},
+			{Name: "z", Type: commands.Float},
+			{Name: "player", Type: players, Optional: true},
+		},
+		Run: func(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+			player := args.String("player")
+			if !args.Has("player") {
+				player = sender.Player
+			}
+			return fmt.Sprintf("%s to %g %g %g", player, args.Float("x"), args.Float("y"), args.Float("z")), nil
+		},
+	}))
+	require.NoError(t, r.Register(&commands.Command{
+		Name: "say",
+		Args: []commands.Arg{{Name: "message", Type: commands.Text}},
+		Run: func(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+			return sender.Name + ": " + args.String("message"), nil
+		},
+	}))
+	assert.Error(t, r.Register(&commands.Command{Name: "teleport", Run: func(context.Context, commands.Sender, commands.Args) (string, error) { return "", nil }}))
+	assert.Error(t, r.Register(&commands.Command{Name: "bad", Args: []commands.Arg{{Name: "a", Type: commands.Text}, {Name: "b", Type: commands.Int}}}))
+
+	admin := commands.Sender{Name: "root", Role: "admin", Player: "p1"}
+	out, err := r.Execute(ctx, admin, "/tp 1 64 -2.5")
+	require.NoError(t, err)
+	assert.Equal(t, "p1 to 1 64 -2.5", out)
+	out, err = r.Execute(ctx, admin, `teleport 0 0 0 BOB`)
+	require.NoError(t, err)
+	assert.Equal(t, "bob to 0 0 0", out)
+	out, err = r.Execute(ctx, commands.ConsoleSender, `/say  hello  "world" `)
+	require.NoError(t, err)
+	assert.Equal(t, `console: hello  "world"`, out)
+
+	for line, code := range map[string]codes.Code{
+		"/tp 1 2":         codes.InvalidArgument,
+		"/tp 1 2 x":       codes.InvalidArgument,
+		"/tp 1 2 3 carol": codes.InvalidArgument,
+		"/tp 1 2 3 bob 4": codes.InvalidArgument,
+		"/fly":            codes.NotFound,
+	} {
+		_, err := r.Execute(ctx, admin, line)
+		assert.Equal(t, code, status.Code(err), line)
+	}
+
+	// players may only ask for help
+	player := commands.Sender{Name: "bob", Role: "player"}
+	_, err = r.Execute(ctx, player, "/tp 0 0 0")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	out, err = r.Execute(ctx, player, "/help")
+	require.NoError(t, err)
+	assert.Equal(t, "/help [command] - lists the commands or explains one", out)
+
+	suggestions, hint := r.Complete(ctx, admin, "/t")
+	assert.Equal(t, []string{"teleport", "tp"}, suggestions)
+	assert.Equal(t, "<command>", hint)
+	suggestions, hint = r.Complete(ctx, admin, "/tp 1 2 3 b")
+	assert.Equal(t, []string{"bill", "bob"}, suggestions)
+	assert.Equal(t, "[player:player]", hint)
+	_, hint = r.Complete(ctx, admin, "/tp 1 ")
+	assert.Equal(t, "<y:float>", hint)
+	suggestions, _ = r.Complete(ctx, player, "/t")
+	assert.Empty(t, suggestions)
+}
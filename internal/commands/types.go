@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type parses and completes one kind of argument.
+type Type interface {
+	// Name is shown in completion hints, e.g. "int".
+	Name() string
+	Parse(ctx context.Context, s string) (interface{}, error)
+	// Complete returns the values starting with prefix, nil if the
+	// values cannot be listed.
+	Complete(ctx context.Context, prefix string) []string
+}
+
+// The basic argument types. String is one word, Text the rest of the
+// line. Int and Float parse to int64 and float64, Duration to a
+// time.Duration written like "10m".
+var (
+	String   Type = stringType{}
+	Text     Type = textType{}
+	Int      Type = intType{}
+	Float    Type = floatType{}
+	Duration Type = durationType{}
+)
+
+type stringType struct{}
+
+func (stringType) Name() string { return "string" }
+
+func (stringType) Parse(ctx context.Context, s string) (interface{}, error) { return s, nil }
+
+func (stringType) Complete(ctx context.Context, prefix string) []string { return nil }
+
+type textType struct{}
+
+func (textType) Name() string { return "text" }
+
+func (textType) Parse(ctx context.Context, s string) (interface{}, error) { return s, nil }
+
+func (textType) Complete(ctx context.Context, prefix string) []string { return nil }
+
+type intType struct{}
+
+func (intType) Name() string { return "int" }
+
+func (intType) Parse(ctx context.Context, s string) (interface{}, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not an integer", s)
+	}
+	return n, nil
+}
+
+func (intType) Complete(ctx context.Context, prefix string) []string { return nil }
+
+type floatType struct{}
+
+func (floatType) Name() string { return "float" }
+
+func (floatType) Parse(ctx context.Context, s string) (interface{}, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a number", s)
+	}
+	return f, nil
+}
+
+func (floatType) Complete(ctx context.Context, prefix string) []string { return nil }
+
+type durationType struct{}
+
+func (durationType) Name() string { return "duration" }
+
+func (durationType) Parse(ctx context.Context, s string) (interface{}, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return nil, fmt.Errorf("%q is not a duration like 10m", s)
+	}
+	return d, nil
+}
+
+func (durationType) Complete(ctx context.Context, prefix string) []string { return nil }
+
+// Enum is a word out of the values returned by Values, which is called
+// for every parse and completion, so the values can change, e.g. the
+// online players. Values match ignoring case.
+type Enum struct {
+	// Kind names the values in hints, e.g. "player".
+	Kind   string
+	Values func(ctx context.Context) []string
+}
+
+// Choice returns an Enum of fixed values.
+func Choice(kind string, values ...string) Type {
+	return Enum{Kind: kind, Values: func(context.Context) []string { return values }}
+}
+
+func (e Enum) Name() string { return e.Kind }
+
+func (e Enum) Parse(ctx context.Context, s string) (interface{}, error) {
+	for _, v := range e.Values(ctx) {
+		if strings.EqualFold(v, s) {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown %s %q", e.Kind, s)
+}
+
+func (e Enum) Complete(ctx context.Context, prefix string) []string {
+	return complete(e.Values(ctx), prefix)
+}
+
+// complete returns the sorted values starting with prefix, ignoring case.
+func complete(values []string, prefix string) []string {
+	var matches []string
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), strings.ToLower(prefix)) {
+			matches = append(matches, v)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// commandType is a command name, for /help.
+type commandType struct {
+	r *Registry
+}
+
+func (commandType) Name() string { return "command" }
+
+func (t commandType) Parse(ctx context.Context, s string) (interface{}, error) {
+	s = strings.TrimPrefix(s, "/")
+	if s == "" {
+		return nil, errors.New("empty command name")
+	}
+	return s, nil
+}
+
+func (t commandType) Complete(ctx context.Context, prefix string) []string {
+	t.r.mu.RLock()
+	defer t.r.mu.RUnlock()
+	names := make([]string, 0, len(t.r.byAlias))
+	for name := range t.r.byAlias {
+		names = append(names, name)
+	}
+	return complete(names, prefix)
+}
@@ -0,0 +1,501 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the server configuration. Values are resolved from, in
+// increasing precedence: built-in defaults, the config file, environment
+// variables and command line flags.
+type Config struct {
+	Listen    ListenConfig    `yaml:"listen" toml:"listen"`
+	GRPC      GRPCConfig      `yaml:"grpc" toml:"grpc"`
+	HTTP      HTTPConfig      `yaml:"http" toml:"http"`
+	Storage   StorageConfig   `yaml:"storage" toml:"storage"`
+	Cache     CacheConfig     `yaml:"cache" toml:"cache"`
+	World     WorldConfig     `yaml:"world" toml:"world"`
+	Auth      AuthConfig      `yaml:"auth" toml:"auth"`
+	RateLimit RateLimitConfig `yaml:"rate_limit" toml:"rate_limit"`
+	Movement  MovementConfig  `yaml:"movement" toml:"movement"`
+	Edit      EditConfig      `yaml:"edit" toml:"edit"`
+	Chat      ChatConfig      `yaml:"chat" toml:"chat"`
+	Commands  CommandsConfig  `yaml:"commands" toml:"commands"`
+	Backup    BackupConfig    `yaml:"backup" toml:"backup"`
+	Keepalive KeepaliveConfig `yaml:"keepalive" toml:"keepalive"`
+	Log       LogConfig       `yaml:"log" toml:"log"`
+	Admin     AdminConfig     `yaml:"admin" toml:"admin"`
+}
+
+type ListenConfig struct {
+	GRPC string `yaml:"grpc" toml:"grpc"`
+	HTTP string `yaml:"http" toml:"http"`
+	// Legacy is the yamux/JSON-RPC listen address, empty disables it.
+	Legacy string `yaml:"legacy" toml:"legacy"`
+}
+
+type GRPCConfig struct {
+	// Reflection registers the server reflection service, so tools like
+	// grpcurl can list the services without the proto files.
+	Reflection bool `yaml:"reflection" toml:"reflection"`
+	// HealthCheckInterval is how often store connectivity is probed for
+	// the health service.
+	HealthCheckInterval Duration `yaml:"health_check_interval" toml:"health_check_interval"`
+}
+
+type HTTPConfig struct {
+	// CORSOrigins lists the origins browsers may call the API from, "*"
+	// allows any.
+	CORSOrigins []string `yaml:"cors_origins" toml:"cors_origins"`
+}
+
+type StorageConfig struct {
+	// Driver is "mysql" or "sqlite".
+	Driver string `yaml:"driver" toml:"driver"`
+	// Path is the database file of the sqlite driver.
+	Path      string `yaml:"path" toml:"path"`
+	Host      string `yaml:"host" toml:"host"`
+	Port      string `yaml:"port" toml:"port"`
+	User      string `yaml:"user" toml:"user"`
+	Password  string `yaml:"password" toml:"password"`
+	Name      string `yaml:"name" toml:"name"`
+	Charset   string `yaml:"charset" toml:"charset"`
+	ParseTime string `yaml:"parse_time" toml:"parse_time"`
+	Loc       string `yaml:"loc" toml:"loc"`
+}
+
+type WorldConfig struct {
+	Seed int64 `yaml:"seed" toml:"seed"`
+	// ViewDistance is the radius, in chunks, sent to players.
+	ViewDistance int `yaml:"view_distance" toml:"view_distance"`
+	// Blocks is a block registry file, empty uses the built-in gocraft
+	// blocks.
+	Blocks string `yaml:"blocks" toml:"blocks"`
+	// Worlds are named worlds besides the default world "world", which
+	// uses Seed. An entry named world configures the default world.
+	Worlds []NamedWorldConfig `yaml:"worlds" toml:"worlds"`
+}
+
+type NamedWorldConfig struct {
+	Name string `yaml:"name" toml:"name"`
+	Seed int64  `yaml:"seed" toml:"seed"`
+	// Generator names the terrain generator clients run, e.g. "default"
+	// or "flat".
+	Generator string `yaml:"generator" toml:"generator"`
+	// Build lists the roles that may change blocks, Enter the roles that
+	// may join. Empty lists allow everyone, also players not logged in.
+	Build []string `yaml:"build" toml:"build"`
+	Enter []string `yaml:"enter" toml:"enter"`
+}
+
+type AuthConfig struct {
+	TokenTTL Duration `yaml:"token_ttl" toml:"token_ttl"`
+	// MaxFailedLogins consecutive failed logins lock the account for
+	// LockoutDuration.
+	MaxFailedLogins int      `yaml:"max_failed_logins" toml:"max_failed_logins"`
+	LockoutDuration Duration `yaml:"lockout_duration" toml:"lockout_duration"`
+	// Whitelist only admits whitelisted users and admins, and no legacy
+	// clients.
+	Whitelist bool `yaml:"whitelist" toml:"whitelist"`
+}
+
+type RateLimitConfig struct {
+	BlockUpdates Rate `yaml:"block_updates" toml:"block_updates"`
+	// BlockBatches limits UpdateBlocks calls and region edits, whatever
+	// their size.
+	BlockBatches Rate `yaml:"block_batches" toml:"block_batches"`
+	// BatchBlocks limits the blocks written by UpdateBlocks, one token per
+	// block. A batch larger than the burst takes the whole burst, so it
+	// waits for a full bucket; Edit.Limits bounds its size.
+	BatchBlocks Rate `yaml:"batch_blocks" toml:"batch_blocks"`
+	StateUpdates Rate `yaml:"state_updates" toml:"state_updates"`
+	// Login limits logins and registrations, which hash a password each.
+	Login Rate `yaml:"login" toml:"login"`
+	// Chat limits the chat messages of each user.
+	Chat Rate `yaml:"chat" toml:"chat"`
+}
+
+// Rate is a token bucket: PerSecond tokens are added every second, up to
+// Burst.
+type Rate struct {
+	PerSecond float64 `yaml:"per_second" toml:"per_second"`
+	Burst     int     `yaml:"burst" toml:"burst"`
+}
+
+// MovementConfig bounds how far players may move between two state
+// updates, speeds are in blocks per second.
+type MovementConfig struct {
+	// Validate turns the checks on.
+	Validate     bool    `yaml:"validate" toml:"validate"`
+	MaxSpeed     float64 `yaml:"max_speed" toml:"max_speed"`
+	MaxRiseSpeed float64 `yaml:"max_rise_speed" toml:"max_rise_speed"`
+	MaxFallSpeed float64 `yaml:"max_fall_speed" toml:"max_fall_speed"`
+	// Tolerance, in blocks, absorbs network jitter and rounding.
+	Tolerance float64 `yaml:"tolerance" toml:"tolerance"`
+}
+
+// EditConfig bounds the region edits of builders.
+type EditConfig struct {
+	// Limits is the most blocks one operation may change, by user role.
+	// They bound UpdateBlocks batches too, anonymous callers count as the
+	// default role.
+	// Roles without a limit cannot use region edits.
+	Limits map[string]int `yaml:"limits" toml:"limits"`
+	// UndoDepth is how many operations each user can undo.
+	UndoDepth int `yaml:"undo_depth" toml:"undo_depth"`
+}
+
+// ChatConfig sets up the chat channels and their moderation.
+type ChatConfig struct {
+	// LocalRadius is how far local chat carries, in blocks.
+	LocalRadius float64 `yaml:"local_radius" toml:"local_radius"`
+	// MaxLength is the most characters of a message.
+	MaxLength int `yaml:"max_length" toml:"max_length"`
+	// Filter lists words masked by asterisks, ignoring case.
+	Filter []string `yaml:"filter" toml:"filter"`
+	// LogRetention is how long the chat log keeps messages, 0 keeps them
+	// forever.
+	LogRetention Duration `yaml:"log_retention" toml:"log_retention"`
+}
+
+// CommandsConfig grants the slash commands.
+type CommandsConfig struct {
+	// Permissions lists the permission nodes of each role, e.g.
+	// "command.tp". "command.*" grants every command and "*" everything.
+	Permissions map[string][]string `yaml:"permissions" toml:"permissions"`
+	// Console reads commands from standard input.
+	Console bool `yaml:"console" toml:"console"`
+}
+
+// CacheConfig bounds the in-memory chunk cache. Chunks changed in memory
+// are not evicted before they are written to the store, so the cache can
+// briefly exceed the bounds under heavy editing.
+type CacheConfig struct {
+	// MaxChunks is the number of chunks kept in memory, 0 turns the cache
+	// off and every change is written to the store right away.
+	MaxChunks int `yaml:"max_chunks" toml:"max_chunks"`
+	// MaxBlocks bounds the stored blocks of the cached chunks, which
+	// dominate its memory use, about 100 bytes each.
+	MaxBlocks int `yaml:"max_blocks" toml:"max_blocks"`
+	// FlushInterval is how often changed chunks are written to the store.
+	// Changes of the last interval are lost if the server crashes.
+	FlushInterval Duration `yaml:"flush_interval" toml:"flush_interval"`
+}
+
+type BackupConfig struct {
+	// Dir receives the snapshots.
+	Dir string `yaml:"dir" toml:"dir"`
+	// Interval between scheduled snapshots, 0 disables them.
+	Interval Duration `yaml:"interval" toml:"interval"`
+	// Keep is how many snapshots are kept, older ones are removed.
+	Keep int `yaml:"keep" toml:"keep"`
+}
+
+type KeepaliveConfig struct {
+	Interval Duration `yaml:"interval" toml:"interval"`
+	Timeout  Duration `yaml:"timeout" toml:"timeout"`
+	// IdleTimeout evicts players and legacy sessions not seen for this long.
+	IdleTimeout Duration `yaml:"idle_timeout" toml:"idle_timeout"`
+}
+
+type LogConfig struct {
+	// Level is debug, info, warn or error. Admins can change it at runtime.
+	Level string `yaml:"level" toml:"level"`
+	// Format is json or text.
+	Format string `yaml:"format" toml:"format"`
+}
+
+type AdminConfig struct {
+	// Token authorizes the /admin HTTP routes, empty disables them.
+	Token string `yaml:"token" toml:"token"`
+}
+
+func validWorldName(name string) bool {
+	if name == "" || len(name) > 64 {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// Duration is a time.Duration written as "30s" or "2m" in config files.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.Duration.String()), nil
+}
+
+// Default returns the configuration used when nothing is overridden.
+func Default() *Config {
+	return &Config{
+		Listen: ListenConfig{
+			GRPC: ":50051",
+			HTTP: ":8080",
+		},
+		GRPC: GRPCConfig{
+			HealthCheckInterval: Duration{10 * time.Second},
+		},
+		HTTP: HTTPConfig{
+			CORSOrigins: []string{"*"},
+		},
+		Storage: StorageConfig{
+			Driver:    "mysql",
+			Path:      "gocraft.db",
+			Host:      "localhost",
+			Port:      "3306",
+			User:      "root",
+			Name:      "app_db",
+			Charset:   "utf8mb4",
+			ParseTime: "True",
+			Loc:       "Local",
+		},
+		World: WorldConfig{
+			ViewDistance: 8,
+		},
+		Auth: AuthConfig{
+			TokenTTL:        Duration{24 * time.Hour},
+			MaxFailedLogins: 5,
+			LockoutDuration: Duration{15 * time.Minute},
+		},
+		RateLimit: RateLimitConfig{
+			BlockUpdates: Rate{PerSecond: 20, Burst: 40},
+			BlockBatches: Rate{PerSecond: 1, Burst: 5},
+			BatchBlocks:  Rate{PerSecond: 2000, Burst: 20000},
+			StateUpdates: Rate{PerSecond: 30, Burst: 60},
+			Login:        Rate{PerSecond: 0.2, Burst: 5},
+			Chat:         Rate{PerSecond: 1, Burst: 5},
+		},
+		Movement: MovementConfig{
+			Validate:     true,
+			MaxSpeed:     12,
+			MaxRiseSpeed: 10,
+			MaxFallSpeed: 60,
+			Tolerance:    1,
+		},
+		Edit: EditConfig{
+			Limits: map[string]int{
+				"player":  1000,
+				"builder": 100000,
+				"admin":   1000000,
+			},
+			UndoDepth: 20,
+		},
+		Chat: ChatConfig{
+			LocalRadius:  32,
+			MaxLength:    256,
+			LogRetention: Duration{30 * 24 * time.Hour},
+		},
+		Commands: CommandsConfig{
+			Permissions: map[string][]string{
+				"player":  {"command.help", "command.list"},
+				"builder": {"command.help", "command.list", "command.tp", "command.time"},
+				"admin":   {"*"},
+			},
+		},
+		Cache: CacheConfig{
+			MaxChunks:     4096,
+			MaxBlocks:     2000000,
+			FlushInterval: Duration{time.Second},
+		},
+		Backup: BackupConfig{
+			Dir:  "backups",
+			Keep: 7,
+		},
+		Keepalive: KeepaliveConfig{
+			Interval:    Duration{30 * time.Second},
+			Timeout:     Duration{10 * time.Second},
+			IdleTimeout: Duration{2 * time.Minute},
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+	}
+}
+
+// Load defines the configuration flags on fs, parses args and resolves
+// the configuration. The result is validated.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	path := fs.String("config", "", "config file (.yaml, .yml or .toml)")
+	flags := defineFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if *path != "" {
+		if err := cfg.loadFile(*path); err != nil {
+			return nil, err
+		}
+	}
+
+	// .env is optional, it only feeds the environment.
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Warn("error loading .env file", "error", err)
+	}
+	if err := cfg.loadEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := flags.apply(fs, cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %v", err)
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(strings.NewReader(string(data)))
+		dec.KnownFields(true)
+		err = dec.Decode(c)
+	case ".toml":
+		dec := toml.NewDecoder(strings.NewReader(string(data)))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(c)
+	default:
+		return fmt.Errorf("config: %s: unknown format %q, use .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("config: %s: %v", path, err)
+	}
+	return nil
+}
+
+// Validate checks the configuration and reports every problem found.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	checkAddr := func(name, addr string, optional bool) {
+		if addr == "" {
+			check(optional, "%s: listen address is required", name)
+			return
+		}
+		_, _, err := net.SplitHostPort(addr)
+		check(err == nil, "%s: %q is not a host:port address", name, addr)
+	}
+	checkAddr("listen.grpc", c.Listen.GRPC, false)
+	checkAddr("listen.http", c.Listen.HTTP, true)
+	checkAddr("listen.legacy", c.Listen.Legacy, true)
+
+	check(c.GRPC.HealthCheckInterval.Duration > 0,
+		"grpc.health_check_interval: must be positive, got %v", c.GRPC.HealthCheckInterval)
+
+	switch c.Storage.Driver {
+	case "mysql":
+		check(c.Storage.Host != "", "storage.host: required for the mysql driver")
+		check(c.Storage.Name != "", "storage.name: required for the mysql driver")
+	case "sqlite":
+		check(c.Storage.Path != "", "storage.path: required for the sqlite driver")
+	default:
+		check(false, "storage.driver: %q is not supported, use mysql or sqlite", c.Storage.Driver)
+	}
+
+	check(c.World.ViewDistance >= 1 && c.World.ViewDistance <= 32,
+		"world.view_distance: %d is out of range 1..32", c.World.ViewDistance)
+	worlds := make(map[string]bool)
+	for i, w := range c.World.Worlds {
+		check(validWorldName(w.Name), "world.worlds[%d].name: %q must be 1 to 64 of a-z, 0-9, _ and -", i, w.Name)
+		check(!worlds[w.Name], "world.worlds[%d].name: %q is used twice", i, w.Name)
+		worlds[w.Name] = true
+	}
+	check(c.Auth.TokenTTL.Duration > 0, "auth.token_ttl: must be positive, got %v", c.Auth.TokenTTL)
+	check(c.Auth.MaxFailedLogins >= 1, "auth.max_failed_logins: must be at least 1, got %d", c.Auth.MaxFailedLogins)
+	check(c.Auth.LockoutDuration.Duration > 0, "auth.lockout_duration: must be positive, got %v", c.Auth.LockoutDuration)
+
+	checkRate := func(name string, r Rate) {
+		check(r.PerSecond > 0, "%s.per_second: must be positive, got %v", name, r.PerSecond)
+		check(r.Burst >= 1, "%s.burst: must be at least 1, got %d", name, r.Burst)
+	}
+	checkRate("rate_limit.block_updates", c.RateLimit.BlockUpdates)
+	checkRate("rate_limit.block_batches", c.RateLimit.BlockBatches)
+	checkRate("rate_limit.batch_blocks", c.RateLimit.BatchBlocks)
+	checkRate("rate_limit.state_updates", c.RateLimit.StateUpdates)
+	checkRate("rate_limit.login", c.RateLimit.Login)
+	checkRate("rate_limit.chat", c.RateLimit.Chat)
+
+	if c.Movement.Validate {
+		check(c.Movement.MaxSpeed > 0, "movement.max_speed: must be positive, got %v", c.Movement.MaxSpeed)
+		check(c.Movement.MaxRiseSpeed > 0, "movement.max_rise_speed: must be positive, got %v", c.Movement.MaxRiseSpeed)
+		check(c.Movement.MaxFallSpeed > 0, "movement.max_fall_speed: must be positive, got %v", c.Movement.MaxFallSpeed)
+		check(c.Movement.Tolerance >= 0, "movement.tolerance: must not be negative, got %v", c.Movement.Tolerance)
+	}
+
+	for role, limit := range c.Edit.Limits {
+		check(limit >= 1, "edit.limits.%s: must be at least 1, got %d", role, limit)
+	}
+	check(c.Edit.UndoDepth >= 0, "edit.undo_depth: must not be negative, got %d", c.Edit.UndoDepth)
+
+	check(c.Chat.LocalRadius > 0, "chat.local_radius: must be positive, got %v", c.Chat.LocalRadius)
+	check(c.Chat.MaxLength >= 1, "chat.max_length: must be at least 1, got %d", c.Chat.MaxLength)
+	check(c.Chat.LogRetention.Duration >= 0, "chat.log_retention: must not be negative, got %v", c.Chat.LogRetention)
+
+	for role, nodes := range c.Commands.Permissions {
+		for _, node := range nodes {
+			check(node != "" && !strings.ContainsAny(node, " /"), "commands.permissions.%s: invalid permission node %q", role, node)
+		}
+	}
+
+	check(c.Cache.MaxChunks >= 0, "cache.max_chunks: must not be negative, got %d", c.Cache.MaxChunks)
+	check(c.Cache.MaxChunks == 0 || c.Cache.MaxBlocks > 0, "cache.max_blocks: must be positive, got %d", c.Cache.MaxBlocks)
+	check(c.Cache.MaxChunks == 0 || c.Cache.FlushInterval.Duration > 0,
+		"cache.flush_interval: must be positive, got %v", c.Cache.FlushInterval)
+	check(c.Backup.Dir != "", "backup.dir: required")
+	check(c.Backup.Interval.Duration >= 0, "backup.interval: must not be negative, got %v", c.Backup.Interval)
+	check(c.Backup.Keep >= 1, "backup.keep: must be at least 1, got %d", c.Backup.Keep)
+
+	check(c.Keepalive.Interval.Duration > 0, "keepalive.interval: must be positive, got %v", c.Keepalive.Interval)
+	check(c.Keepalive.Timeout.Duration > 0, "keepalive.timeout: must be positive, got %v", c.Keepalive.Timeout)
+	check(c.Keepalive.IdleTimeout.Duration > c.Keepalive.Interval.Duration,
+		"keepalive.idle_timeout: %v must be longer than keepalive.interval %v", c.Keepalive.IdleTimeout, c.Keepalive.Interval)
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		check(false, "log.level: %q is not one of debug, info, warn, error", c.Log.Level)
+	}
+	check(c.Log.Format == "json" || c.Log.Format == "text", "log.format: %q is not json or text", c.Log.Format)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+}
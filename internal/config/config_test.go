@@ -0,0 +1,75 @@
+package config_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func load(t *testing.T, args ...string) (*config.Config, error) {
+	return config.Load(flag.NewFlagSet("test", flag.ContinueOnError), args)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	err := os.WriteFile(path, []byte(`
+[listen]
+grpc = ":6000"
+http = ":6001"
+
+[world]
+seed = 42
+view_distance = 4
+
+[auth]
+token_ttl = "1h"
+`), 0o644)
+	assert.NoError(t, err)
+
+	t.Setenv("GOCRAFT_HTTP_ADDR", ":7001")
+	t.Setenv("GOCRAFT_VIEW_DISTANCE", "6")
+	t.Setenv("DB_HOST", "db.example")
+
+	cfg, err := load(t, "-config", path, "-view-distance", "10")
+	assert.NoError(t, err)
+	assert.Equal(t, ":6000", cfg.Listen.GRPC)   // file
+	assert.Equal(t, ":7001", cfg.Listen.HTTP)   // env over file
+	assert.Equal(t, 10, cfg.World.ViewDistance) // flag over env
+	assert.Equal(t, int64(42), cfg.World.Seed)  // file
+	assert.Equal(t, time.Hour, cfg.Auth.TokenTTL.Duration)
+	assert.Equal(t, "db.example", cfg.Storage.Host) // env over default
+	assert.Equal(t, "3306", cfg.Storage.Port)       // default
+}
+
+func TestLoadYAML(t *testing.T) {
+	cfg, err := load(t, "-config", "../../config.example.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, *config.Default(), *cfg)
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(path, []byte("listen:\n  grpc: \"50051\"\nstorage:\n  driver: postgres\n"), 0o644)
+	assert.NoError(t, err)
+
+	_, err = load(t, "-config", path, "-token-ttl", "0s")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `listen.grpc: "50051" is not a host:port address`)
+	assert.Contains(t, err.Error(), `storage.driver: "postgres" is not supported`)
+	assert.Contains(t, err.Error(), "auth.token_ttl: must be positive")
+
+	_, err = load(t, "-config", filepath.Join(dir, "config.ini"))
+	assert.Error(t, err)
+
+	path = filepath.Join(dir, "typo.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("wrold:\n  seed: 1\n"), 0o644))
+	_, err = load(t, "-config", path)
+	assert.ErrorContains(t, err, "wrold")
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envVars maps environment variables onto the configuration. The DB_*
+// names are the ones InitStore always read.
+var envVars = map[string]func(c *Config) interface{}{
+	"GOCRAFT_GRPC_ADDR":         func(c *Config) interface{} { return &c.Listen.GRPC },
+	"GOCRAFT_REFLECTION":        func(c *Config) interface{} { return &c.GRPC.Reflection },
+	"GOCRAFT_HTTP_ADDR":         func(c *Config) interface{} { return &c.Listen.HTTP },
+	"GOCRAFT_LEGACY_ADDR":       func(c *Config) interface{} { return &c.Listen.Legacy },
+	"GOCRAFT_CORS_ORIGINS":      func(c *Config) interface{} { return &c.HTTP.CORSOrigins },
+	"GOCRAFT_WORLD_SEED":        func(c *Config) interface{} { return &c.World.Seed },
+	"GOCRAFT_BLOCKS":            func(c *Config) interface{} { return &c.World.Blocks },
+	"GOCRAFT_VIEW_DISTANCE":     func(c *Config) interface{} { return &c.World.ViewDistance },
+	"GOCRAFT_TOKEN_TTL":         func(c *Config) interface{} { return &c.Auth.TokenTTL },
+	"GOCRAFT_MAX_FAILED_LOGINS": func(c *Config) interface{} { return &c.Auth.MaxFailedLogins },
+	"GOCRAFT_LOCKOUT_DURATION":  func(c *Config) interface{} { return &c.Auth.LockoutDuration },
+	"GOCRAFT_WHITELIST":         func(c *Config) interface{} { return &c.Auth.Whitelist },
+	"GOCRAFT_VALIDATE_MOVEMENT": func(c *Config) interface{} { return &c.Movement.Validate },
+	"GOCRAFT_IDLE_TIMEOUT":      func(c *Config) interface{} { return &c.Keepalive.IdleTimeout },
+	"GOCRAFT_STORAGE_DRIVER":    func(c *Config) interface{} { return &c.Storage.Driver },
+	"GOCRAFT_STORAGE_PATH":      func(c *Config) interface{} { return &c.Storage.Path },
+	"GOCRAFT_LOG_LEVEL":         func(c *Config) interface{} { return &c.Log.Level },
+	"GOCRAFT_LOG_FORMAT":        func(c *Config) interface{} { return &c.Log.Format },
+	"GOCRAFT_ADMIN_TOKEN":       func(c *Config) interface{} { return &c.Admin.Token },
+	"GOCRAFT_CACHE_MAX_CHUNKS":  func(c *Config) interface{} { return &c.Cache.MaxChunks },
+	"GOCRAFT_BACKUP_DIR":        func(c *Config) interface{} { return &c.Backup.Dir },
+	"GOCRAFT_BACKUP_INTERVAL":   func(c *Config) interface{} { return &c.Backup.Interval },
+	"DB_HOST":                   func(c *Config) interface{} { return &c.Storage.Host },
+	"DB_PORT":                   func(c *Config) interface{} { return &c.Storage.Port },
+	"DB_USER":                   func(c *Config) interface{} { return &c.Storage.User },
+	"DB_PASSWORD":               func(c *Config) interface{} { return &c.Storage.Password },
+	"DB_NAME":                   func(c *Config) interface{} { return &c.Storage.Name },
+	"DB_CHARSET":                func(c *Config) interface{} { return &c.Storage.Charset },
+	"DB_PARSE_TIME":             func(c *Config) interface{} { return &c.Storage.ParseTime },
+	"DB_LOC":                    func(c *Config) interface{} { return &c.Storage.Loc },
+}
+
+// loadEnv applies the environment variables that are set and not empty.
+func (c *Config) loadEnv(lookup func(string) (string, bool)) error {
+	for name, field := range envVars {
+		value, ok := lookup(name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := setValue(field(c), value); err != nil {
+			return fmt.Errorf("config: %s=%q: %v", name, value, err)
+		}
+	}
+	return nil
+}
+
+func setValue(field interface{}, value string) error {
+	switch f := field.(type) {
+	case *string:
+		*f = value
+	case *[]string:
+		*f = strings.Split(value, ",")
+	case *bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("not a boolean")
+		}
+		*f = v
+	case *int:
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("not an integer")
+		}
+		*f = v
+	case *int64:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("not an integer")
+		}
+		*f = v
+	case *Duration:
+		v, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("not a duration, use e.g. 30s or 2h")
+		}
+		f.Duration = v
+	default:
+		return fmt.Errorf("unsupported field type %T", field)
+	}
+	return nil
+}
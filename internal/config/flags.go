@@ -0,0 +1,65 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+)
+
+// flagValues holds the raw flag values. Only flags given on the command
+// line are applied, so unset flags never hide file or env values.
+type flagValues struct {
+	values map[string]*string
+	fields map[string]func(c *Config) interface{}
+}
+
+func defineFlags(fs *flag.FlagSet) *flagValues {
+	f := &flagValues{
+		values: make(map[string]*string),
+		fields: make(map[string]func(c *Config) interface{}),
+	}
+	define := func(name, usage string, field func(c *Config) interface{}) {
+		f.values[name] = fs.String(name, "", usage)
+		f.fields[name] = field
+	}
+	defineBool := func(name, usage string, field func(c *Config) interface{}) {
+		value := new(string)
+		fs.BoolFunc(name, usage, func(s string) error {
+			*value = s
+			return nil
+		})
+		f.values[name] = value
+		f.fields[name] = field
+	}
+	define("grpc", "gRPC listen address", func(c *Config) interface{} { return &c.Listen.GRPC })
+	defineBool("reflection", "register gRPC server reflection", func(c *Config) interface{} { return &c.GRPC.Reflection })
+	define("http", "HTTP listen address, empty disables it", func(c *Config) interface{} { return &c.Listen.HTTP })
+	define("l", "legacy yamux/JSON-RPC listen address, e.g. 0.0.0.0:8421", func(c *Config) interface{} { return &c.Listen.Legacy })
+	define("storage", "storage driver, mysql or sqlite", func(c *Config) interface{} { return &c.Storage.Driver })
+	define("db", "sqlite database file", func(c *Config) interface{} { return &c.Storage.Path })
+	define("seed", "world seed", func(c *Config) interface{} { return &c.World.Seed })
+	define("blocks", "block registry file, empty uses the built-in blocks", func(c *Config) interface{} { return &c.World.Blocks })
+	define("view-distance", "view distance in chunks", func(c *Config) interface{} { return &c.World.ViewDistance })
+	defineBool("console", "read commands from standard input", func(c *Config) interface{} { return &c.Commands.Console })
+	defineBool("whitelist", "only admit whitelisted users", func(c *Config) interface{} { return &c.Auth.Whitelist })
+	define("token-ttl", "lifetime of auth tokens", func(c *Config) interface{} { return &c.Auth.TokenTTL })
+	define("keepalive", "interval between keepalive pings", func(c *Config) interface{} { return &c.Keepalive.Interval })
+	define("keepalive-timeout", "time to wait for a keepalive ack", func(c *Config) interface{} { return &c.Keepalive.Timeout })
+	define("idle-timeout", "evict players not seen for this long", func(c *Config) interface{} { return &c.Keepalive.IdleTimeout })
+	define("log-level", "log level: debug, info, warn or error", func(c *Config) interface{} { return &c.Log.Level })
+	define("log-format", "log format: json or text", func(c *Config) interface{} { return &c.Log.Format })
+	return f
+}
+
+func (f *flagValues) apply(fs *flag.FlagSet, c *Config) error {
+	var err error
+	fs.Visit(func(fl *flag.Flag) {
+		field, ok := f.fields[fl.Name]
+		if !ok || err != nil {
+			return
+		}
+		if serr := setValue(field(c), *f.values[fl.Name]); serr != nil {
+			err = fmt.Errorf("config: -%s=%q: %v", fl.Name, *f.values[fl.Name], serr)
+		}
+	})
+	return err
+}
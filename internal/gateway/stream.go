@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// pushStream lets the gRPC server-streaming handlers of the services write
+// to a WebSocket instead of an HTTP/2 stream.
+type pushStream[T any] struct {
+	ctx  context.Context
+	send func(proto.Message) error
+}
+
+func (s *pushStream[T]) Send(m *T) error {
+	return s.send(any(m).(proto.Message))
+}
+
+func (s *pushStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *pushStream[T]) SendMsg(m interface{}) error {
+	return s.send(m.(proto.Message))
+}
+
+func (s *pushStream[T]) RecvMsg(m interface{}) error {
+	return errors.New("gateway: streams are server-to-client only")
+}
+
+func (s *pushStream[T]) SetHeader(metadata.MD) error  { return nil }
+func (s *pushStream[T]) SendHeader(metadata.MD) error { return nil }
+func (s *pushStream[T]) SetTrailer(metadata.MD)       {}
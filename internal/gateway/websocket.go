@@ -0,0 +1,493 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/middleware"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
+	"github.com/perlinson/gocraft-server/internal/services"
+	authpb "github.com/perlinson/gocraft-server/proto/auth"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	chatpb "github.com/perlinson/gocraft-server/proto/chat"
+	gatewaypb "github.com/perlinson/gocraft-server/proto/gateway"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// cancelMethod stops the subscription started by the frame with the
+	// same id.
+	cancelMethod = "cancel"
+
+	maxFrameSize = 1 << 20
+	pongWait     = 60 * time.Second
+	pingInterval = 25 * time.Second
+	writeWait    = 10 * time.Second
+)
+
+// Gateway exposes the block, player and auth services to browsers over a
+// WebSocket. Every frame names a method; unary methods are answered with
+// one frame, streaming methods keep pushing frames with the request id
+// until cancelled or the socket closes.
+//
+// A connection acts for the user of the session token given when it is
+// opened, in the Authorization header or, as browsers can't set headers
+// on WebSockets, the token query parameter. A successful auth.Login on the
+// connection switches it to the new session, auth.Logout of that session
+// makes it anonymous again.
+type Gateway struct {
+	methods    map[string]*method
+	upgrader   websocket.Upgrader
+	limiter    *ratelimit.Limiter
+	auth       *services.AuthService
+	moderation *services.ModerationService
+}
+
+type method struct {
+	// budget is the rate limit budget of the method, empty if unlimited.
+	budget     string
+	newRequest func() proto.Message
+	unary      func(ctx context.Context, req proto.Message) (proto.Message, error)
+	stream     func(ctx context.Context, req proto.Message, send func(proto.Message) error) error
+}
+
+func NewGateway(blockService *services.BlockService, playerService *services.PlayerService, authService *services.AuthService) *Gateway {
+	g := &Gateway{
+		methods: make(map[string]*method),
+		auth:    authService,
+	}
+
+	g.methods["auth.Login"] = limited(ratelimit.Login, unary(authService.Login))
+	g.methods["auth.Logout"] = unary(authService.Logout)
+	g.methods["block.FetchChunk"] = unary(blockService.FetchChunk)
+	g.methods["block.UpdateBlock"] = limited(ratelimit.BlockUpdates, unary(blockService.UpdateBlock))
+	g.methods["block.UpdateBlocks"] = limited(ratelimit.BlockBatches, unary(blockService.UpdateBlocks))
+	g.methods["block.ListBlockTypes"] = unary(blockService.ListBlockTypes)
+	g.methods["block.StreamChunk"] = stream[blockpb.ChunkRequest, blockpb.ChunkUpdate](blockService.StreamChunk)
+	g.methods["block.StreamView"] = stream[blockpb.StreamViewRequest, blockpb.ViewUpdate](blockService.StreamView)
+	g.methods["block.UpdateView"] = limited(ratelimit.StateUpdates, unary(blockService.UpdateView))
+	g.methods["player.UpdateState"] = limited(ratelimit.StateUpdates, unary(playerService.UpdateState))
+	g.methods["player.RemovePlayer"] = unary(playerService.RemovePlayer)
+	g.methods["player.Heartbeat"] = unary(playerService.Heartbeat)
+	g.methods["player.StreamEvents"] = stream[playerpb.StreamEventsRequest, playerpb.PlayerEvent](playerService.StreamEvents)
+	g.methods["player.ListWorlds"] = unary(playerService.ListWorlds)
+	g.methods["player.JoinWorld"] = unary(playerService.JoinWorld)
+	return g
+}
+
+// SetChatService adds the chat methods. The chat service applies its own
+// rate limit.
+func (g *Gateway) SetChatService(chatService *services.ChatService) {
+	g.methods["chat.SendMessage"] = unary(chatService.SendMessage)
+	g.methods["chat.StreamMessages"] = stream[chatpb.StreamMessagesRequest, chatpb.ChatMessage](chatService.StreamMessages)
+	g.methods["chat.JoinTeam"] = unary(chatService.JoinTeam)
+	g.methods["chat.Mute"] = unary(chatService.Mute)
+	g.methods["chat.Unmute"] = unary(chatService.Unmute)
+}
+
+// SetCommandService adds the command methods. Commands share the chat
+// budget.
+func (g *Gateway) SetCommandService(commandService *services.CommandService) {
+	g.methods["command.Execute"] = limited(ratelimit.Chat, unary(commandService.Execute))
+	g.methods["command.Complete"] = unary(commandService.Complete)
+	g.methods["command.ListCommands"] = unary(commandService.ListCommands)
+}
+
+// SetAllowedOrigins admits browser clients served from the given origins,
+// e.g. a CDN, "*" admits any. By default only pages from the server's own
+// host may connect.
+func (g *Gateway) SetAllowedOrigins(origins []string) {
+	g.upgrader.CheckOrigin = middleware.CheckOrigin(origins)
+}
+
+// SetModeration lets kicks and bans close connections: they end with a
+// close frame giving the reason.
+func (g *Gateway) SetModeration(moderation *services.ModerationService) {
+	g.moderation = moderation
+}
+
+// SetRateLimiter limits the calls of each connection with the budgets the
+// gRPC interceptor uses, keyed by the client IP.
+func (g *Gateway) SetRateLimiter(l *ratelimit.Limiter) {
+	g.limiter = l
+}
+
+func limited(budget string, m *method) *method {
+	m.budget = budget
+	return m
+}
+
+// unary adapts a gRPC unary handler.
+func unary[Req, Resp any, PReq interface {
+	*Req
+	proto.Message
+}, PResp interface {
+	*Resp
+	proto.Message
+}](f func(context.Context, PReq) (PResp, error)) *method {
+	return &method{
+		newRequest: func() proto.Message { return PReq(new(Req)) },
+		unary: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			resp, err := f(ctx, req.(PReq))
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		},
+	}
+}
+
+// stream adapts a gRPC server-streaming handler, e.g. StreamChunk.
+func stream[Req, Resp any, PReq interface {
+	*Req
+	proto.Message
+}, S any](f func(PReq, S) error) *method {
+	return &method{
+		newRequest: func() proto.Message { return PReq(new(Req)) },
+		stream: func(ctx context.Context, req proto.Message, send func(proto.Message) error) error {
+			s := &pushStream[Resp]{ctx: ctx, send: send}
+			return f(req.(PReq), any(s).(S))
+		},
+	}
+}
+
+// Types the generated stream interfaces are instantiated with.
+var (
+	_ blockpb.BlockService_StreamChunkServer    = (*pushStream[blockpb.ChunkUpdate])(nil)
+	_ blockpb.BlockService_StreamViewServer     = (*pushStream[blockpb.ViewUpdate])(nil)
+	_ playerpb.PlayerService_StreamEventsServer = (*pushStream[playerpb.PlayerEvent])(nil)
+	_ chatpb.ChatService_StreamMessagesServer   = (*pushStream[chatpb.ChatMessage])(nil)
+)
+
+// RegisterRoutes 注册 WebSocket 路由
+func (g *Gateway) RegisterRoutes(r *gin.Engine) {
+	r.GET("/ws", g.handleWS)
+}
+
+func (g *Gateway) handleWS(c *gin.Context) {
+	conn := &conn{
+		gw:   g,
+		ip:   c.ClientIP(),
+		subs: make(map[uint64]context.CancelFunc),
+	}
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if token == "" {
+		token = c.Query("token")
+	}
+	if token != "" && !conn.setToken(token) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		return
+	}
+
+	ws, err := g.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "websocket upgrade failed", "remote", c.Request.RemoteAddr, "error", err)
+		return
+	}
+	// 每个连接一个会话 ID，贯穿该连接上所有调用的日志
+	ctx := logging.WithSessionID(context.Background(), logging.NewRequestID())
+	ctx = logging.WithClientIP(ctx, conn.ip)
+	if g.moderation != nil {
+		var release func()
+		ctx, release = g.moderation.Track(ctx, conn.ip, conn.user)
+		defer release()
+	}
+	conn.ws = ws
+	conn.ctx, conn.cancel = context.WithCancel(ctx)
+	slog.InfoContext(ctx, "websocket client connected", "remote", ws.RemoteAddr().String(), "user_id", conn.user())
+	go conn.ping()
+	conn.serve()
+	slog.InfoContext(ctx, "websocket client closed connection", "remote", ws.RemoteAddr().String())
+}
+
+// conn is a single WebSocket client.
+type conn struct {
+	gw     *Gateway
+	ws     *websocket.Conn
+	ip     string
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	wmu sync.Mutex // serializes writes
+
+	mu   sync.Mutex
+	subs map[uint64]context.CancelFunc
+	// token is the session the connection acts for, userID its user. The
+	// token is checked on every call, so revoked sessions stop working;
+	// userID stays to find the connection when its user is kicked.
+	token  string
+	userID string
+}
+
+// setToken switches the connection to session token and reports whether
+// the token is valid.
+func (c *conn) setToken(token string) bool {
+	if c.gw.auth == nil {
+		return false
+	}
+	userID, ok := c.gw.auth.ResolveUser(token)
+	if !ok {
+		return false
+	}
+	c.mu.Lock()
+	c.token, c.userID = token, userID
+	c.mu.Unlock()
+	return true
+}
+
+// user returns the ID of the user the connection was opened or logged in
+// as, empty for anonymous connections.
+func (c *conn) user() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.userID
+}
+
+// callContext adds the user of the connection's session to ctx, unless the
+// session has ended.
+func (c *conn) callContext(ctx context.Context) context.Context {
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+	if token == "" {
+		return ctx
+	}
+	if userID, ok := c.gw.auth.ResolveUser(token); ok {
+		return logging.WithUserID(ctx, userID)
+	}
+	return ctx
+}
+
+// sessionChanged follows auth.Login and auth.Logout calls on the
+// connection.
+func (c *conn) sessionChanged(in, out proto.Message) {
+	switch out := out.(type) {
+	case *authpb.LoginResponse:
+		c.setToken(out.Token)
+	case *authpb.LogoutResponse:
+		c.mu.Lock()
+		if in.(*authpb.LogoutRequest).Token == c.token {
+			c.token, c.userID = "", ""
+		}
+		c.mu.Unlock()
+	}
+}
+
+// request is a decoded frame together with the encoding it arrived in,
+// replies and pushes use the same encoding.
+type request struct {
+	id      uint64
+	method  string
+	payload []byte
+	binary  bool
+}
+
+type jsonFrame struct {
+	ID      uint64          `json:"id"`
+	Method  string          `json:"method"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func (c *conn) serve() {
+	defer c.ws.Close()
+	defer c.cancel()
+
+	c.ws.SetReadLimit(maxFrameSize)
+	c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		typ, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.ws.SetReadDeadline(time.Now().Add(pongWait))
+
+		req, err := decodeFrame(typ, data)
+		if err != nil {
+			c.reply(&request{binary: typ == websocket.BinaryMessage}, nil, err)
+			continue
+		}
+		c.handle(req)
+	}
+}
+
+func (c *conn) handle(req *request) {
+	if req.method == cancelMethod {
+		c.mu.Lock()
+		if cancel, ok := c.subs[req.id]; ok {
+			cancel()
+			delete(c.subs, req.id)
+		}
+		c.mu.Unlock()
+		c.reply(req, nil, nil)
+		return
+	}
+
+	m, ok := c.gw.methods[req.method]
+	if !ok {
+		c.reply(req, nil, fmt.Errorf("unknown method %q", req.method))
+		return
+	}
+	callCtx := logging.WithRequestID(c.callContext(c.ctx), logging.NewRequestID())
+	if m.budget != "" {
+		if err := c.gw.limiter.Check(m.budget, ratelimit.ClientKeys(callCtx, c.ip)...); err != nil {
+			c.reply(req, nil, err)
+			return
+		}
+	}
+	in := m.newRequest()
+	if err := c.unmarshal(req, in); err != nil {
+		c.reply(req, nil, fmt.Errorf("bad payload for %s: %v", req.method, err))
+		return
+	}
+
+	if m.unary != nil {
+		out, err := m.unary(callCtx, in)
+		if err == nil {
+			c.sessionChanged(in, out)
+		}
+		c.reply(req, out, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(callCtx)
+	c.mu.Lock()
+	if old, ok := c.subs[req.id]; ok {
+		old()
+	}
+	c.subs[req.id] = cancel
+	c.mu.Unlock()
+
+	go func() {
+		err := m.stream(ctx, in, func(out proto.Message) error {
+			return c.write(req, out, nil)
+		})
+		if ctx.Err() == nil {
+			// the stream ended on its own, tell the client why.
+			c.reply(req, nil, err)
+		}
+		cancel()
+	}()
+}
+
+func decodeFrame(typ int, data []byte) (*request, error) {
+	if typ == websocket.BinaryMessage {
+		var frame gatewaypb.Frame
+		if err := proto.Unmarshal(data, &frame); err != nil {
+			return nil, fmt.Errorf("bad frame: %v", err)
+		}
+		return &request{id: frame.Id, method: frame.Method, payload: frame.Payload, binary: true}, nil
+	}
+	var frame jsonFrame
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return nil, fmt.Errorf("bad frame: %v", err)
+	}
+	return &request{id: frame.ID, method: frame.Method, payload: frame.Payload}, nil
+}
+
+func (c *conn) unmarshal(req *request, m proto.Message) error {
+	if len(req.payload) == 0 {
+		return nil
+	}
+	if req.binary {
+		return proto.Unmarshal(req.payload, m)
+	}
+	return protojson.Unmarshal(req.payload, m)
+}
+
+func (c *conn) reply(req *request, out proto.Message, err error) {
+	if werr := c.write(req, out, err); werr != nil {
+		slog.WarnContext(c.ctx, "websocket write failed", "remote", c.ws.RemoteAddr().String(), "error", werr)
+	}
+}
+
+func (c *conn) write(req *request, out proto.Message, callErr error) error {
+	var (
+		typ  int
+		data []byte
+		err  error
+	)
+	errText := ""
+	if callErr != nil {
+		st := status.Convert(callErr)
+		errText = fmt.Sprintf("%s: %s", st.Code(), st.Message())
+	}
+
+	if req.binary {
+		frame := &gatewaypb.Frame{Id: req.id, Method: req.method, Error: errText}
+		if out != nil {
+			if frame.Payload, err = proto.Marshal(out); err != nil {
+				return err
+			}
+		}
+		typ = websocket.BinaryMessage
+		data, err = proto.Marshal(frame)
+	} else {
+		frame := &jsonFrame{ID: req.id, Method: req.method, Error: errText}
+		if out != nil {
+			if frame.Payload, err = protojson.Marshal(out); err != nil {
+				return err
+			}
+		}
+		typ = websocket.TextMessage
+		data, err = json.Marshal(frame)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.ws.WriteMessage(typ, data)
+}
+
+func (c *conn) ping() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			c.kicked()
+			return
+		case <-ticker.C:
+			c.wmu.Lock()
+			err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			c.wmu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// kicked closes the socket if the connection was kicked or banned, telling
+// the client why.
+func (c *conn) kicked() {
+	cause := context.Cause(c.ctx)
+	if status.Code(cause) != codes.Aborted {
+		return
+	}
+	msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, status.Convert(cause).Message())
+	c.wmu.Lock()
+	c.ws.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+	c.wmu.Unlock()
+	c.ws.Close()
+}
@@ -0,0 +1,151 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/gateway"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	authpb "github.com/perlinson/gocraft-server/proto/auth"
+	gatewaypb "github.com/perlinson/gocraft-server/proto/gateway"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type frame struct {
+	ID      uint64          `json:"id"`
+	Method  string          `json:"method"`
+	Payload json.RawMessage `json:"payload"`
+	Error   string          `json:"error"`
+}
+
+func TestGateway(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	gateway.NewGateway(nil, services.NewPlayerService(nil), nil).RegisterRoutes(router)
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	assert.NoError(t, err)
+	defer ws.Close()
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	// JSON 帧：订阅玩家事件后上报其他玩家状态。订阅在服务端注册之前的事件会丢失，
+	// 所以不断加入新玩家，直到收到加入事件
+	require.NoError(t, ws.WriteJSON(frame{ID: 1, Method: "player.StreamEvents", Payload: json.RawMessage(`{"id":"watcher"}`)}))
+	var event frame
+	for id := uint64(2); event.ID == 0; id++ {
+		payload := fmt.Sprintf(`{"id":"steve%d","state":{"y":16}}`, id)
+		require.NoError(t, ws.WriteJSON(frame{ID: id, Method: "player.UpdateState", Payload: json.RawMessage(payload)}))
+		for {
+			var f frame
+			require.NoError(t, ws.ReadJSON(&f))
+			if f.ID == 1 {
+				event = f
+				break
+			}
+			if f.ID == id {
+				assert.Empty(t, f.Error)
+				break
+			}
+		}
+	}
+	var joined struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(event.Payload, &joined))
+	assert.JSONEq(t, fmt.Sprintf(`{"id":%q,"state":{"y":16},"world":"world"}`, joined.ID), string(event.Payload))
+
+	// protobuf 帧：未上线玩家的心跳返回错误，跳过之前调用剩下的 JSON 帧
+	payload, _ := proto.Marshal(&playerpb.HeartbeatRequest{Id: "nobody"})
+	data, _ := proto.Marshal(&gatewaypb.Frame{Id: 3, Method: "player.Heartbeat", Payload: payload})
+	assert.NoError(t, ws.WriteMessage(websocket.BinaryMessage, data))
+	typ := websocket.TextMessage
+	for typ == websocket.TextMessage {
+		typ, data, err = ws.ReadMessage()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, websocket.BinaryMessage, typ)
+	var reply gatewaypb.Frame
+	assert.NoError(t, proto.Unmarshal(data, &reply))
+	assert.Equal(t, uint64(3), reply.Id)
+	assert.Contains(t, reply.Error, "NotFound")
+}
+
+// 测试令牌认证、来源检查和踢出
+func TestGatewayAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	authService := services.NewAuthService(s)
+	playerService := services.NewPlayerService(nil)
+	moderation := services.NewModerationService(s, authService, playerService)
+	commandService := services.NewCommandService(s, commands.NewRegistry(nil))
+	_, err = s.CreateUser(ctx, "alice", "secret", "")
+	require.NoError(t, err)
+	login, err := authService.Login(ctx, &authpb.LoginRequest{Username: "alice", Password: "secret"})
+	require.NoError(t, err)
+
+	router := gin.New()
+	gw := gateway.NewGateway(nil, playerService, authService)
+	gw.SetAllowedOrigins([]string{"https://play.example.com"})
+	gw.SetModeration(moderation)
+	gw.SetCommandService(commandService)
+	gw.RegisterRoutes(router)
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+	call := func(ws *websocket.Conn, id uint64, method string) frame {
+		require.NoError(t, ws.WriteJSON(frame{ID: id, Method: method, Payload: json.RawMessage(`{}`)}))
+		var f frame
+		require.NoError(t, ws.ReadJSON(&f))
+		return f
+	}
+
+	// 其他网站的页面不能连接，无效的令牌被拒绝
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://evil.example.com"}})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	_, resp, err = websocket.DefaultDialer.Dial(url+"?token=bogus", nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// 匿名连接调用需要登录的方法返回 Unauthenticated
+	anon, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://play.example.com"}})
+	require.NoError(t, err)
+	defer anon.Close()
+	anon.SetReadDeadline(time.Now().Add(5 * time.Second))
+	assert.Contains(t, call(anon, 1, "command.ListCommands").Error, "Unauthenticated")
+
+	// 带令牌的连接以该用户身份调用
+	ws, _, err := websocket.DefaultDialer.Dial(url+"?token="+neturl.QueryEscape(login.Token), nil)
+	require.NoError(t, err)
+	defer ws.Close()
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	assert.Empty(t, call(ws, 1, "command.ListCommands").Error)
+
+	// 踢出用户时连接以关闭帧结束
+	_, err = moderation.Kick(ctx, "alice", "afk")
+	require.NoError(t, err)
+	_, _, err = ws.ReadMessage()
+	var closeErr *websocket.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, "kicked: afk", closeErr.Text)
+}
@@ -0,0 +1,181 @@
+// Package gocraftdb imports worlds of the single-player gocraft client,
+// which keeps them in a bolt database file with three buckets:
+//
+//   - block: a nested bucket per chunk, keyed by the chunk ID. Its keys are
+//     block positions, its values block types.
+//   - chunk: chunk versions, keyed by the chunk ID.
+//   - camera: the player position, x, y, z, rx and ry as float32.
+//
+// Positions and chunk IDs are three little endian int32, block types one.
+package gocraftdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/store"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	blockBucket  = []byte("block")
+	chunkBucket  = []byte("chunk")
+	cameraBucket = []byte("camera")
+)
+
+// World is the content of a gocraft database.
+type World struct {
+	Blocks map[store.Vec3]int
+	// Versions are the chunk versions by chunk ID.
+	Versions map[store.Vec3]string
+	// Camera is nil if the player never moved.
+	Camera *Camera
+}
+
+// Camera is the saved player position.
+type Camera struct {
+	X, Y, Z, RX, RY float32
+}
+
+// Read loads the world in the gocraft database at path. The file is
+// opened read only; gocraft must not be running, it locks the file.
+func Read(path string) (*World, error) {
+	db, err := bolt.Open(path, 0o400, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("gocraftdb: opening %s: %v", path, err)
+	}
+	defer db.Close()
+
+	w := &World{
+		Blocks:   make(map[store.Vec3]int),
+		Versions: make(map[store.Vec3]string),
+	}
+	err = db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(blockBucket) == nil && tx.Bucket(chunkBucket) == nil {
+			return fmt.Errorf("no block or chunk bucket, not a gocraft world")
+		}
+		if err := readBlocks(tx.Bucket(blockBucket), w.Blocks); err != nil {
+			return err
+		}
+		if err := readVersions(tx.Bucket(chunkBucket), w.Versions); err != nil {
+			return err
+		}
+		w.Camera = readCamera(tx.Bucket(cameraBucket))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gocraftdb: %s: %v", path, err)
+	}
+	return w, nil
+}
+
+func readBlocks(bkt *bolt.Bucket, blocks map[store.Vec3]int) error {
+	if bkt == nil {
+		return nil
+	}
+	return bkt.ForEach(func(k, v []byte) error {
+		// 值为空的键是区块的子 bucket
+		chunk := bkt.Bucket(k)
+		if v != nil || chunk == nil {
+			return nil
+		}
+		return chunk.ForEach(func(k, v []byte) error {
+			id, err := decodeVec3(k)
+			if err != nil {
+				return err
+			}
+			if len(v) != 4 {
+				return fmt.Errorf("block %v: %d bytes are not a block type", id, len(v))
+			}
+			blocks[id] = int(int32(binary.LittleEndian.Uint32(v)))
+			return nil
+		})
+	})
+}
+
+func readVersions(bkt *bolt.Bucket, versions map[store.Vec3]string) error {
+	if bkt == nil {
+		return nil
+	}
+	return bkt.ForEach(func(k, v []byte) error {
+		id, err := decodeVec3(k)
+		if err != nil {
+			return err
+		}
+		versions[id] = string(v)
+		return nil
+	})
+}
+
+func readCamera(bkt *bolt.Bucket) *Camera {
+	if bkt == nil {
+		return nil
+	}
+	v := bkt.Get(cameraBucket)
+	if len(v) != 20 {
+		return nil
+	}
+	var c Camera
+	if err := binary.Read(bytes.NewReader(v), binary.LittleEndian, &c); err != nil {
+		return nil
+	}
+	return &c
+}
+
+func decodeVec3(b []byte) (store.Vec3, error) {
+	if len(b) != 12 {
+		return store.Vec3{}, fmt.Errorf("%d bytes are not a position", len(b))
+	}
+	return store.Vec3{
+		X: int32(binary.LittleEndian.Uint32(b)),
+		Y: int32(binary.LittleEndian.Uint32(b[4:])),
+		Z: int32(binary.LittleEndian.Uint32(b[8:])),
+	}, nil
+}
+
+// Stats summarizes an import.
+type Stats struct {
+	Blocks int
+	// Versions is the number of chunk versions carried over.
+	Versions int
+	Camera   bool
+}
+
+// Import writes w into the world called world of s, the blocks in one
+// transaction. Imported blocks replace stored ones. Chunks the store
+// already knew get a new version, so clients refetch them; new chunks keep
+// their gocraft version. The camera is replaced when w has one.
+func Import(s *store.Store, world string, w *World) (Stats, error) {
+	var stats Stats
+	known := make(map[store.Vec3]bool, len(w.Versions))
+	for id := range w.Versions {
+		if s.GetChunkVersion(world, id) != "" {
+			known[id] = true
+		}
+	}
+
+	// 方块和受影响区块的版本在同一事务中写入
+	if _, err := s.UpdateBlocks(world, w.Blocks, store.GenerateChunkVersion()); err != nil {
+		return stats, fmt.Errorf("gocraftdb: storing blocks: %v", err)
+	}
+	stats.Blocks = len(w.Blocks)
+	for id, version := range w.Versions {
+		if known[id] || version == "" {
+			continue
+		}
+		if err := s.UpdateChunkVersion(world, id, version); err != nil {
+			return stats, fmt.Errorf("gocraftdb: storing chunk version: %v", err)
+		}
+		stats.Versions++
+	}
+
+	if c := w.Camera; c != nil {
+		if err := s.UpdateCamera(c.X, c.Y, c.Z, c.RX, c.RY); err != nil {
+			return stats, fmt.Errorf("gocraftdb: storing camera: %v", err)
+		}
+		stats.Camera = true
+	}
+	return stats, nil
+}
@@ -0,0 +1,79 @@
+package gocraftdb_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/gocraftdb"
+	"github.com/perlinson/gocraft-server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func encode(t *testing.T, v interface{}) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, binary.Write(&buf, binary.LittleEndian, v))
+	return buf.Bytes()
+}
+
+// writeWorld writes a world the way gocraft does.
+func writeWorld(t *testing.T, path string) {
+	db, err := bolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	err = db.Update(func(tx *bolt.Tx) error {
+		blocks, err := tx.CreateBucket([]byte("block"))
+		require.NoError(t, err)
+		chunk, err := blocks.CreateBucket(encode(t, [3]int32{0, 0, 0}))
+		require.NoError(t, err)
+		require.NoError(t, chunk.Put(encode(t, [3]int32{1, 20, 2}), encode(t, int32(5))))
+		require.NoError(t, chunk.Put(encode(t, [3]int32{3, 10, 4}), encode(t, int32(0))))
+		chunk, err = blocks.CreateBucket(encode(t, [3]int32{-1, 0, 2}))
+		require.NoError(t, err)
+		require.NoError(t, chunk.Put(encode(t, [3]int32{-5, 30, 70}), encode(t, int32(8))))
+
+		chunks, err := tx.CreateBucket([]byte("chunk"))
+		require.NoError(t, err)
+		require.NoError(t, chunks.Put(encode(t, [3]int32{0, 0, 0}), []byte("v0")))
+		require.NoError(t, chunks.Put(encode(t, [3]int32{-1, 0, 2}), []byte("v1")))
+
+		camera, err := tx.CreateBucket([]byte("camera"))
+		require.NoError(t, err)
+		return camera.Put([]byte("camera"), encode(t, [5]float32{1.5, 20, -3, 0.5, 0.25}))
+	})
+	require.NoError(t, err)
+}
+
+func TestImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gocraft.db")
+	writeWorld(t, path)
+
+	world, err := gocraftdb.Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[store.Vec3]int{{X: 1, Y: 20, Z: 2}: 5, {X: 3, Y: 10, Z: 4}: 0, {X: -5, Y: 30, Z: 70}: 8}, world.Blocks)
+	assert.Equal(t, &gocraftdb.Camera{X: 1.5, Y: 20, Z: -3, RX: 0.5, RY: 0.25}, world.Camera)
+
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "server.db")})
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.UpdateChunkVersion(store.DefaultWorld, store.Vec3{}, "server"))
+
+	stats, err := gocraftdb.Import(s, store.DefaultWorld, world)
+	require.NoError(t, err)
+	assert.Equal(t, gocraftdb.Stats{Blocks: 3, Versions: 1, Camera: true}, stats)
+	w, err := s.GetBlock(store.DefaultWorld, store.Vec3{X: -5, Y: 30, Z: 70})
+	require.NoError(t, err)
+	assert.Equal(t, 8, w)
+	assert.Equal(t, "v1", s.GetChunkVersion(store.DefaultWorld, store.Vec3{X: -1, Z: 2}))
+	assert.NotContains(t, []string{"server", "v0"}, s.GetChunkVersion(store.DefaultWorld, store.Vec3{}), "known chunks get a new version")
+	x, y, _, _, _ := s.GetCamera()
+	assert.Equal(t, float32(1.5), x)
+	assert.Equal(t, float32(20), y)
+
+	_, err = gocraftdb.Read(filepath.Join(t.TempDir(), "missing.db"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys clients may set. A missing request ID is generated.
+const (
+	RequestIDKey     = "x-request-id"
+	SessionIDKey     = "x-session-id"
+	authorizationKey = "authorization"
+)
+
+// UserResolver maps a bearer token to a user ID.
+type UserResolver func(token string) (userID string, ok bool)
+
+// UnaryServerInterceptor puts the request, user and session IDs and the
+// client IP into the context of each call and logs its outcome. Successful calls are logged
+// at debug level, they are the hot path.
+func UnaryServerInterceptor(resolve UserResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withCallIDs(ctx, resolve)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams.
+func StreamServerInterceptor(resolve UserResolver) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withCallIDs(ss.Context(), resolve)
+		start := time.Now()
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func withCallIDs(ctx context.Context, resolve UserResolver) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	requestID := first(RequestIDKey)
+	if requestID == "" {
+		requestID = NewRequestID()
+	}
+	ctx = WithRequestID(ctx, requestID)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			ip = p.Addr.String()
+		}
+		ctx = WithClientIP(ctx, ip)
+	}
+	if sessionID := first(SessionIDKey); sessionID != "" {
+		ctx = WithSessionID(ctx, sessionID)
+	}
+	if token := strings.TrimPrefix(first(authorizationKey), "Bearer "); token != "" && resolve != nil {
+		if userID, ok := resolve(token); ok {
+			ctx = WithUserID(ctx, userID)
+		}
+	}
+	return ctx
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	level := slog.LevelDebug
+	args := []any{
+		"method", method,
+		"code", status.Code(err).String(),
+		"duration", time.Since(start),
+	}
+	if err != nil {
+		level = slog.LevelWarn
+		args = append(args, "error", err)
+	}
+	slog.Log(ctx, level, "rpc", args...)
+}
@@ -0,0 +1,32 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes 注册日志级别查询与修改路由，auth 限制为管理员访问
+func RegisterRoutes(r *gin.Engine, level *slog.LevelVar, auth gin.HandlerFunc) {
+	r.GET("/admin/log/level", auth, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"level": level.Level().String()})
+	})
+	r.PUT("/admin/log/level", auth, func(c *gin.Context) {
+		var req struct {
+			Level string `json:"level"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		l, err := ParseLevel(req.Level)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		slog.InfoContext(c.Request.Context(), "log level changed", "from", level.Level().String(), "to", l.String())
+		level.Set(l)
+		c.JSON(http.StatusOK, gin.H{"level": l.String()})
+	})
+}
@@ -0,0 +1,56 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptorCorrelatesIDs(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	var buf bytes.Buffer
+	level := logging.Setup(&buf, "json", slog.LevelInfo)
+
+	resolve := func(token string) (string, bool) { return "user-1", token == "secret" }
+	interceptor := logging.UnaryServerInterceptor(resolve)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		logging.RequestIDKey, "req-1",
+		logging.SessionIDKey, "sess-1",
+		"authorization", "Bearer secret",
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/block.BlockService/UpdateBlock"}
+
+	// successful calls are only logged at debug level
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Zero(t, buf.Len())
+
+	level.Set(slog.LevelDebug)
+	_, err = interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		assert.Equal(t, "req-1", logging.RequestID(ctx))
+		assert.Equal(t, "user-1", logging.UserID(ctx))
+		assert.Equal(t, "sess-1", logging.SessionID(ctx))
+		return nil, status.Error(codes.NotFound, "no such chunk")
+	})
+	require.Error(t, err)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "NotFound", record["code"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "user-1", record["user_id"])
+	assert.Equal(t, "sess-1", record["session_id"])
+}
@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "gocraft"
+
+var (
+	RPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	RPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request latency by method. Streams are measured until they end.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	BlockUpdates = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "block_updates_total",
+		Help:      "Blocks written by players.",
+	})
+
+	ChunkFetches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chunk_fetches_total",
+		Help:      "Chunk fetches; result is hit when the client's cached version was current.",
+	}, []string{"result"})
+
+	StoreQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_query_duration_seconds",
+		Help:      "Store query latency by operation.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation"})
+
+	ChunkStreamSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chunk_stream_subscribers",
+		Help:      "Active StreamChunk subscribers.",
+	})
+
+	ChunkViews = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chunk_views",
+		Help:      "Active StreamView streams.",
+	})
+
+	MovementViolations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "movement_violations_total",
+		Help:      "Player moves rejected by UpdateState, by violation.",
+	}, []string{"violation"})
+
+	ChunkCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chunk_cache_lookups_total",
+		Help:      "Chunk cache lookups; result is miss when the chunk was loaded from the store.",
+	}, []string{"result"})
+
+	ChunkCacheFlushes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chunk_cache_flushes_total",
+		Help:      "Batches of changed chunks written to the store, by result.",
+	}, []string{"result"})
+)
+
+// RegisterChunkCache exports the number of chunks in the chunk cache and
+// of those not yet written to the store, as reported by count.
+func RegisterChunkCache(count func() (cached, dirty int)) {
+	for _, state := range []string{"cached", "dirty"} {
+		state := state
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "chunk_cache_chunks",
+			Help:        "Chunks in the chunk cache; dirty chunks are not yet written to the store.",
+			ConstLabels: prometheus.Labels{"state": state},
+		}, func() float64 {
+			cached, dirty := count()
+			if state == "dirty" {
+				return float64(dirty)
+			}
+			return float64(cached)
+		})
+	}
+}
+
+// RegisterOnlinePlayers exports the number of online players of a
+// transport, e.g. "grpc" or "legacy", as reported by count.
+func RegisterOnlinePlayers(transport string, count func() int) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "online_players",
+		Help:        "Players currently online by transport.",
+		ConstLabels: prometheus.Labels{"transport": transport},
+	}, func() float64 {
+		return float64(count())
+	})
+}
+
+// RegisterRoutes 注册 /metrics 路由
+func RegisterRoutes(r *gin.Engine) {
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
+
+// UnaryServerInterceptor counts and times unary RPCs.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor counts and times streaming RPCs.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observe(method string, start time.Time, err error) {
+	RPCRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	RPCDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
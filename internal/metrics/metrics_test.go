@@ -0,0 +1,46 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := metrics.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/block.BlockService/UpdateBlock"}
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	fail := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad block")
+	}
+
+	// the counters are global, so compare with their values before the calls
+	okCount := metrics.RPCRequests.WithLabelValues(info.FullMethod, "OK")
+	failCount := metrics.RPCRequests.WithLabelValues(info.FullMethod, "InvalidArgument")
+	okBefore, failBefore := testutil.ToFloat64(okCount), testutil.ToFloat64(failCount)
+
+	interceptor(context.Background(), nil, info, ok)
+	interceptor(context.Background(), nil, info, ok)
+	interceptor(context.Background(), nil, info, fail)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(okCount)-okBefore)
+	assert.Equal(t, 1.0, testutil.ToFloat64(failCount)-failBefore)
+}
+
+func TestGormPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(metrics.GormPlugin{}))
+
+	var n int
+	assert.NoError(t, db.Raw("SELECT 1").Scan(&n).Error)
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.StoreQueryDuration, "gocraft_store_query_duration_seconds"))
+}
@@ -1,159 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-// versions:
-// - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.29.3
-// source: player.proto
-
-package player
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
-
-const (
-	PlayerService_UpdateState_FullMethodName  = "/player.PlayerService/UpdateState"
-	PlayerService_RemovePlayer_FullMethodName = "/player.PlayerService/RemovePlayer"
-)
-
-// PlayerServiceClient is the client API for PlayerService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type PlayerServiceClient interface {
-	UpdateState(ctx context.Context, in *UpdateStateRequest, opts ...grpc.CallOption) (*UpdateStateResponse, error)
-	RemovePlayer(ctx context.Context, in *RemovePlayerRequest, opts ...grpc.CallOption) (*RemovePlayerResponse, error)
-}
-
-type playerServiceClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewPlayerServiceClient(cc grpc.ClientConnInterface) PlayerServiceClient {
-	return &playerServiceClient{cc}
-}
-
-func (c *playerServiceClient) UpdateState(ctx context.Context, in *UpdateStateRequest, opts ...grpc.CallOption) (*UpdateStateResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateStateResponse)
-	err := c.cc.Invoke(ctx, PlayerService_UpdateState_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *playerServiceClient) RemovePlayer(ctx context.Context, in *RemovePlayerRequest, opts ...grpc.CallOption) (*RemovePlayerResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RemovePlayerResponse)
-	err := c.cc.Invoke(ctx, PlayerService_RemovePlayer_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// PlayerServiceServer is the server API for PlayerService service.
-// All implementations must embed UnimplementedPlayerServiceServer
-// for forward compatibility.
-type PlayerServiceServer interface {
-	UpdateState(context.Context, *UpdateStateRequest) (*UpdateStateResponse, error)
-	RemovePlayer(context.Context, *RemovePlayerRequest) (*RemovePlayerResponse, error)
-	mustEmbedUnimplementedPlayerServiceServer()
-}
-
-// UnimplementedPlayerServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedPlayerServiceServer struct{}
-
-func (UnimplementedPlayerServiceServer) UpdateState(context.Context, *UpdateStateRequest) (*UpdateStateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateState not implemented")
-}
-func (UnimplementedPlayerServiceServer) RemovePlayer(context.Context, *RemovePlayerRequest) (*RemovePlayerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemovePlayer not implemented")
-}
-func (UnimplementedPlayerServiceServer) mustEmbedUnimplementedPlayerServiceServer() {}
-func (UnimplementedPlayerServiceServer) testEmbeddedByValue()                       {}
-
-// UnsafePlayerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PlayerServiceServer will
-// result in compilation errors.
-type UnsafePlayerServiceServer interface {
-	mustEmbedUnimplementedPlayerServiceServer()
-}
-
-func RegisterPlayerServiceServer(s grpc.ServiceRegistrar, srv PlayerServiceServer) {
-	// If the following call pancis, it indicates UnimplementedPlayerServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
-	}
-	s.RegisterService(&PlayerService_ServiceDesc, srv)
-}
-
-func _PlayerService_UpdateState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateStateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PlayerServiceServer).UpdateState(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: PlayerService_UpdateState_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlayerServiceServer).UpdateState(ctx, req.(*UpdateStateRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _PlayerService_RemovePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemovePlayerRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PlayerServiceServer).RemovePlayer(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: PlayerService_RemovePlayer_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlayerServiceServer).RemovePlayer(ctx, req.(*RemovePlayerRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-// PlayerService_ServiceDesc is the grpc.ServiceDesc for PlayerService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var PlayerService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "player.PlayerService",
-	HandlerType: (*PlayerServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "UpdateState",
-			Handler:    _PlayerService_UpdateState_Handler,
-		},
-		{
-			MethodName: "RemovePlayer",
-			Handler:    _PlayerService_RemovePlayer_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "player.proto",
-}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+
+	"github.com/perlinson/gocraft-server/internal/logging"
+	authpb "github.com/perlinson/gocraft-server/proto/auth"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	commandpb "github.com/perlinson/gocraft-server/proto/command"
+	editpb "github.com/perlinson/gocraft-server/proto/edit"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RetryAfterKey is the response header carrying the retry delay in
+// seconds, for clients that do not decode status details.
+const RetryAfterKey = "retry-after"
+
+// Methods maps the limited RPCs to their budget.
+var Methods = map[string]string{
+	blockpb.BlockService_UpdateBlock_FullMethodName:   BlockUpdates,
+	blockpb.BlockService_UpdateBlocks_FullMethodName:  BlockBatches,
+	editpb.EditService_Fill_FullMethodName:            BlockBatches,
+	editpb.EditService_Replace_FullMethodName:         BlockBatches,
+	editpb.EditService_Hollow_FullMethodName:          BlockBatches,
+	editpb.EditService_Walls_FullMethodName:           BlockBatches,
+	editpb.EditService_Paste_FullMethodName:           BlockBatches,
+	editpb.EditService_Move_FullMethodName:            BlockBatches,
+	editpb.EditService_Undo_FullMethodName:            BlockBatches,
+	editpb.EditService_Redo_FullMethodName:            BlockBatches,
+	editpb.EditService_ImportSchematic_FullMethodName: BlockBatches,
+	blockpb.BlockService_UpdateView_FullMethodName:    StateUpdates,
+	commandpb.CommandService_Execute_FullMethodName:   Chat,
+	playerpb.PlayerService_UpdateState_FullMethodName: StateUpdates,
+	authpb.AuthService_Login_FullMethodName:           Login,
+}
+
+// UnaryServerInterceptor rejects calls over budget with ResourceExhausted.
+// It must run after the logging interceptor, which resolves the user.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		budget, ok := Methods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		if err := l.Check(budget, ClientKeys(ctx, peerIP(ctx))...); err != nil {
+			if d, ok := RetryAfter(err); ok {
+				grpc.SetHeader(ctx, metadata.Pairs(RetryAfterKey, RetryAfterSeconds(d)))
+			}
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ClientKeys identifies the caller by the user ID in ctx if authenticated
+// and by its IP address, if known. Calls are charged to all keys.
+func ClientKeys(ctx context.Context, ip string) []string {
+	var keys []string
+	if id := logging.UserID(ctx); id != "" {
+		keys = append(keys, "user:"+id)
+	}
+	if ip != "" || len(keys) == 0 {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/status"
+)
+
+// Middleware rejects requests over budget with 429 and a Retry-After
+// header. It limits per IP, and placed after RequireAuth per user too.
+func (l *Limiter) Middleware(budget string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := l.Check(budget, ClientKeys(c.Request.Context(), c.ClientIP())...)
+		if err == nil {
+			c.Next()
+			return
+		}
+		if d, ok := RetryAfter(err); ok {
+			c.Header("Retry-After", RetryAfterSeconds(d))
+		}
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": status.Convert(err).Message()})
+	}
+}
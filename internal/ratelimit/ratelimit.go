@@ -0,0 +1,184 @@
+// Package ratelimit keeps a token bucket per budget and client, a client
+// being a user or an IP address. Calls are charged to the buckets of both
+// the user and the IP, so neither switching accounts nor sharing one from
+// many addresses gets fresh buckets.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Budgets, each configured separately.
+const (
+	BlockUpdates = "block_updates"
+	BlockBatches = "block_batches"
+	BatchBlocks  = "batch_blocks"
+	StateUpdates = "state_updates"
+	Login        = "login"
+	Chat         = "chat"
+)
+
+// Limiter holds the buckets of all budgets. A nil *Limiter allows
+// everything.
+type Limiter struct {
+	rates map[string]config.Rate
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+type bucketKey struct {
+	budget string
+	client string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// New creates a limiter with the configured budgets.
+func New(cfg config.RateLimitConfig) *Limiter {
+	return &Limiter{
+		rates: map[string]config.Rate{
+			BlockUpdates: cfg.BlockUpdates,
+			BlockBatches: cfg.BlockBatches,
+			BatchBlocks:  cfg.BatchBlocks,
+			StateUpdates: cfg.StateUpdates,
+			Login:        cfg.Login,
+			Chat:         cfg.Chat,
+		},
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of each client in budget, or from
+// none of them. When one is empty it returns false and how long to wait
+// until all have a token.
+func (l *Limiter) Allow(budget string, clients ...string) (bool, time.Duration) {
+	return l.AllowN(budget, 1, clients...)
+}
+
+// AllowN is Allow taking n tokens at once. Asking for more than the burst
+// of the budget takes the whole burst, so large requests wait for a full
+// bucket instead of never being allowed.
+func (l *Limiter) AllowN(budget string, n int, clients ...string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	r, ok := l.rates[budget]
+	if !ok {
+		return true, 0
+	}
+	n = min(n, r.Burst)
+
+	now := time.Now()
+	reserved := make([]*rate.Reservation, 0, len(clients))
+	cancel := func() {
+		for _, res := range reserved {
+			res.CancelAt(now)
+		}
+	}
+	var delay time.Duration
+	for _, client := range clients {
+		res := l.bucket(bucketKey{budget, client}, r, now).ReserveN(now, n)
+		if !res.OK() {
+			cancel()
+			return false, 0
+		}
+		reserved = append(reserved, res)
+		delay = max(delay, res.DelayFrom(now))
+	}
+	if delay > 0 {
+		cancel()
+		return false, delay
+	}
+	return true, 0
+}
+
+func (l *Limiter) bucket(key bucketKey, r config.Rate, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(r.PerSecond), r.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+	return b.limiter
+}
+
+// Check is Allow returning a ResourceExhausted error carrying the retry
+// delay when the call is rejected.
+func (l *Limiter) Check(budget string, clients ...string) error {
+	return l.CheckN(budget, 1, clients...)
+}
+
+// CheckN is Check taking n tokens.
+func (l *Limiter) CheckN(budget string, n int, clients ...string) error {
+	if ok, retryAfter := l.AllowN(budget, n, clients...); !ok {
+		return Exhausted(fmt.Sprintf("rate limit for %s exceeded", budget), retryAfter)
+	}
+	return nil
+}
+
+// Exhausted builds a ResourceExhausted error with a RetryInfo detail, so
+// clients know when to try again.
+func Exhausted(msg string, retryAfter time.Duration) error {
+	st := status.Newf(codes.ResourceExhausted, "%s, retry in %v", msg, retryAfter.Round(time.Millisecond))
+	if withInfo, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
+
+// RetryAfter returns the retry delay of an error built by Exhausted.
+func RetryAfter(err error) (time.Duration, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.RetryDelay.AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// RetryAfterSeconds formats d for the Retry-After header, rounding up so
+// clients never retry too early.
+func RetryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// Prune drops buckets that have refilled completely, they behave like new
+// ones, checking every interval until ctx is done.
+func (l *Limiter) Prune(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.mu.Lock()
+			for key, b := range l.buckets {
+				r := l.rates[key.budget]
+				refill := time.Duration(float64(r.Burst) / r.PerSecond * float64(time.Second))
+				if now.Sub(b.lastUsed) > refill {
+					delete(l.buckets, key)
+				}
+			}
+			l.mu.Unlock()
+		}
+	}
+}
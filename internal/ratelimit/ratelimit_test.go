@@ -0,0 +1,78 @@
+package ratelimit_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptorBudgets(t *testing.T) {
+	limiter := ratelimit.New(config.RateLimitConfig{
+		BlockUpdates: config.Rate{PerSecond: 1, Burst: 2},
+		StateUpdates: config.Rate{PerSecond: 1, Burst: 1},
+		Login:        config.Rate{PerSecond: 1, Burst: 1},
+	})
+	interceptor := limiter.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(ctx context.Context, method string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	ip := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242}})
+	alice := logging.WithUserID(ip, "alice")
+	updateBlock := blockpb.BlockService_UpdateBlock_FullMethodName
+
+	require.NoError(t, call(alice, updateBlock))
+	require.NoError(t, call(alice, updateBlock))
+	err := call(alice, updateBlock)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	retryAfter, ok := ratelimit.RetryAfter(err)
+	assert.True(t, ok)
+	assert.InDelta(t, time.Second, retryAfter, float64(50*time.Millisecond))
+
+	// calls are charged to both the user and the IP: other accounts from
+	// the same IP and the same account from another IP are limited too
+	other := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 4242}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(ip, updateBlock)))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(logging.WithUserID(ip, "bob"), updateBlock)))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(logging.WithUserID(other, "alice"), updateBlock)))
+	assert.NoError(t, call(logging.WithUserID(other, "bob"), updateBlock))
+
+	// rejected calls take no token from the other bucket, and the RPCs
+	// have separate budgets
+	updateState := "/player.PlayerService/UpdateState"
+	assert.NoError(t, call(alice, updateState))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(logging.WithUserID(other, "alice"), updateState)))
+	assert.NoError(t, call(logging.WithUserID(other, "carol"), updateState))
+
+	// unlimited RPCs pass
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, call(alice, blockpb.BlockService_FetchChunk_FullMethodName))
+	}
+}
+
+func TestCheckNLargerThanBurst(t *testing.T) {
+	limiter := ratelimit.New(config.RateLimitConfig{BatchBlocks: config.Rate{PerSecond: 1, Burst: 3}})
+
+	// more than the burst takes the whole bucket instead of failing forever
+	require.NoError(t, limiter.CheckN(ratelimit.BatchBlocks, 10, "alice"))
+	err := limiter.CheckN(ratelimit.BatchBlocks, 10, "alice")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	retryAfter, ok := ratelimit.RetryAfter(err)
+	assert.True(t, ok)
+	assert.InDelta(t, 3*time.Second, retryAfter, float64(50*time.Millisecond))
+	assert.NoError(t, limiter.CheckN(ratelimit.BatchBlocks, 2, "bob"))
+}
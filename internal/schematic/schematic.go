@@ -0,0 +1,226 @@
+// Package schematic reads and writes schematics, portable copies of a
+// cuboid region of the world.
+//
+// A schematic file is gzip compressed JSON:
+//
+//	{
+//	  "format": "gocraft-schematic",
+//	  "version": 1,
+//	  "size": [4, 3, 5],
+//	  "palette": {"1": "grass", "5": "wood"},
+//	  "blocks": [[0, 0, 0, 1], [1, 0, 0, 5], [1, 1, 0, 0]]
+//	}
+//
+// size is the extent of the region along x, y and z. blocks lists the
+// blocks stored in the region as x, y, z, w with positions relative to its
+// minimum corner; w 0 is air, a dug out block. palette names the other
+// block types, imports map them to the IDs of the importing server by
+// name. The generated terrain is not part of a schematic, only blocks
+// players changed.
+package schematic
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/perlinson/gocraft-server/internal/blocks"
+	"github.com/perlinson/gocraft-server/internal/store"
+)
+
+// Format and Version identify the file format.
+const (
+	Format  = "gocraft-schematic"
+	Version = 1
+)
+
+// Schematic is a copied region.
+type Schematic struct {
+	Size store.Vec3
+	// Blocks maps positions relative to the minimum corner to block
+	// types.
+	Blocks map[store.Vec3]int
+}
+
+// file is the JSON layout.
+type file struct {
+	Format  string            `json:"format"`
+	Version int               `json:"version"`
+	Size    [3]int32          `json:"size"`
+	Palette map[string]string `json:"palette"`
+	Blocks  [][4]int32        `json:"blocks"`
+}
+
+// Source is where Export reads blocks, a store.Store or a cache in front
+// of it.
+type Source interface {
+	RangeBlocks(world string, id store.Vec3, f func(bid store.Vec3, w int, version string)) error
+}
+
+// Export copies the stored blocks of world in the cuboid from min to max,
+// both inclusive, reading the chunks it spans.
+func Export(s Source, world string, min, max store.Vec3) (*Schematic, error) {
+	sc := &Schematic{
+		Size:   store.Vec3{X: max.X - min.X + 1, Y: max.Y - min.Y + 1, Z: max.Z - min.Z + 1},
+		Blocks: make(map[store.Vec3]int),
+	}
+	if sc.Size.X <= 0 || sc.Size.Y <= 0 || sc.Size.Z <= 0 {
+		return nil, fmt.Errorf("schematic: %v is not the minimum corner of %v", min, max)
+	}
+	lo, hi := min.Chunkid(), max.Chunkid()
+	for p := lo.X; p <= hi.X; p++ {
+		for q := lo.Z; q <= hi.Z; q++ {
+			err := s.RangeBlocks(world, store.Vec3{X: p, Z: q}, func(id store.Vec3, w int, _ string) {
+				if id.X >= min.X && id.X <= max.X && id.Y >= min.Y && id.Y <= max.Y && id.Z >= min.Z && id.Z <= max.Z {
+					sc.Blocks[store.Vec3{X: id.X - min.X, Y: id.Y - min.Y, Z: id.Z - min.Z}] = w
+				}
+			})
+			if err != nil {
+				return nil, fmt.Errorf("schematic: reading chunk (%d, %d): %v", p, q, err)
+			}
+		}
+	}
+	return sc, nil
+}
+
+// Write encodes the schematic, naming its block types after r.
+func (sc *Schematic) Write(w io.Writer, r *blocks.Registry) error {
+	f := file{
+		Format:  Format,
+		Version: Version,
+		Size:    [3]int32{sc.Size.X, sc.Size.Y, sc.Size.Z},
+		Palette: make(map[string]string),
+		Blocks:  make([][4]int32, 0, len(sc.Blocks)),
+	}
+	for p, id := range sc.Blocks {
+		if int32(id) != blocks.Air {
+			t, ok := r.Lookup(int32(id))
+			if !ok {
+				return fmt.Errorf("schematic: block type %d is not in the registry", id)
+			}
+			f.Palette[strconv.Itoa(id)] = t.Name
+		}
+		f.Blocks = append(f.Blocks, [4]int32{p.X, p.Y, p.Z, int32(id)})
+	}
+	// 排序使相同区域导出的文件相同
+	sort.Slice(f.Blocks, func(i, j int) bool {
+		a, b := f.Blocks[i], f.Blocks[j]
+		for k := 0; k < 3; k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(&f); err != nil {
+		return fmt.Errorf("schematic: %v", err)
+	}
+	return zw.Close()
+}
+
+// Read decodes a schematic, mapping its block types to the IDs of r by
+// name.
+func Read(rd io.Reader, r *blocks.Registry) (*Schematic, error) {
+	zr, err := gzip.NewReader(rd)
+	if err != nil {
+		return nil, fmt.Errorf("schematic: %v", err)
+	}
+	defer zr.Close()
+	var f file
+	if err := json.NewDecoder(zr).Decode(&f); err != nil {
+		return nil, fmt.Errorf("schematic: %v", err)
+	}
+	if f.Format != Format {
+		return nil, fmt.Errorf("schematic: format %q is not %s", f.Format, Format)
+	}
+	if f.Version != Version {
+		return nil, fmt.Errorf("schematic: version %d is not supported", f.Version)
+	}
+
+	names := make(map[string]int32)
+	for _, t := range r.Types() {
+		names[t.Name] = t.ID
+	}
+	ids := map[int32]int{blocks.Air: int(blocks.Air)}
+	for key, name := range f.Palette {
+		id, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("schematic: palette key %q is not a block type", key)
+		}
+		local, ok := names[name]
+		if !ok {
+			return nil, fmt.Errorf("schematic: block type %q is not in the registry", name)
+		}
+		ids[int32(id)] = int(local)
+	}
+
+	sc := &Schematic{
+		Size:   store.Vec3{X: f.Size[0], Y: f.Size[1], Z: f.Size[2]},
+		Blocks: make(map[store.Vec3]int, len(f.Blocks)),
+	}
+	for _, b := range f.Blocks {
+		p := store.Vec3{X: b[0], Y: b[1], Z: b[2]}
+		if p.X < 0 || p.X >= sc.Size.X || p.Y < 0 || p.Y >= sc.Size.Y || p.Z < 0 || p.Z >= sc.Size.Z {
+			return nil, fmt.Errorf("schematic: block %v is outside the size %v", p, sc.Size)
+		}
+		id, ok := ids[b[3]]
+		if !ok {
+			return nil, fmt.Errorf("schematic: block type %d is not in the palette", b[3])
+		}
+		sc.Blocks[p] = id
+	}
+	return sc, nil
+}
+
+// Place returns the blocks of the schematic with its minimum corner at
+// at, rotated clockwise around the y axis by rotation degrees, a multiple
+// of 90.
+func (sc *Schematic) Place(at store.Vec3, rotation int32) (map[store.Vec3]int, error) {
+	rotation, err := NormalizeRotation(rotation)
+	if err != nil {
+		return nil, err
+	}
+	placed := make(map[store.Vec3]int, len(sc.Blocks))
+	for p, w := range sc.Blocks {
+		t := Transform(p, sc.Size, rotation, false, false)
+		placed[store.Vec3{X: at.X + t.X, Y: at.Y + t.Y, Z: at.Z + t.Z}] = w
+	}
+	return placed, nil
+}
+
+// NormalizeRotation returns rotation in 0..359, it must be a multiple
+// of 90.
+func NormalizeRotation(rotation int32) (int32, error) {
+	r := (rotation%360 + 360) % 360
+	if r%90 != 0 {
+		return 0, fmt.Errorf("rotation %d is not a multiple of 90", rotation)
+	}
+	return r, nil
+}
+
+// Transform mirrors and then rotates p, a position in a region of size,
+// clockwise around the y axis by rotation degrees, normalized. The result
+// lies in the rotated region, its minimum corner staying at the origin.
+func Transform(p, size store.Vec3, rotation int32, mirrorX, mirrorZ bool) store.Vec3 {
+	x, z := p.X, p.Z
+	if mirrorX {
+		x = size.X - 1 - x
+	}
+	if mirrorZ {
+		z = size.Z - 1 - z
+	}
+	switch rotation {
+	case 90:
+		x, z = size.Z-1-z, x
+	case 180:
+		x, z = size.X-1-x, size.Z-1-z
+	case 270:
+		x, z = z, size.X-1-x
+	}
+	return store.Vec3{X: x, Y: p.Y, Z: z}
+}
@@ -0,0 +1,57 @@
+package schematic_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/blocks"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/schematic"
+	"github.com/perlinson/gocraft-server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+
+	// the region spans four chunks
+	_, err = s.UpdateBlocks(store.DefaultWorld, map[store.Vec3]int{
+		{X: -1, Y: 5, Z: -1}: 5,
+		{X: 0, Y: 5, Z: 0}:   0,
+		{X: 1, Y: 6, Z: 0}:   8,
+		{X: 9, Y: 5, Z: 0}:   1,
+	}, "v")
+	require.NoError(t, err)
+	sc, err := schematic.Export(s, store.DefaultWorld, store.Vec3{X: -1, Y: 5, Z: -1}, store.Vec3{X: 1, Y: 6, Z: 0})
+	require.NoError(t, err)
+	assert.Equal(t, store.Vec3{X: 3, Y: 2, Z: 2}, sc.Size)
+	assert.Equal(t, map[store.Vec3]int{{X: 0, Y: 0, Z: 0}: 5, {X: 1, Y: 0, Z: 1}: 0, {X: 2, Y: 1, Z: 1}: 8}, sc.Blocks)
+
+	var buf bytes.Buffer
+	require.NoError(t, sc.Write(&buf, blocks.Default()))
+
+	// the importing server numbers wood and plank differently
+	other, err := blocks.New([]blocks.Type{{ID: 1, Name: "plank"}, {ID: 2, Name: "wood"}})
+	require.NoError(t, err)
+	read, err := schematic.Read(bytes.NewReader(buf.Bytes()), other)
+	require.NoError(t, err)
+	assert.Equal(t, map[store.Vec3]int{{X: 0, Y: 0, Z: 0}: 2, {X: 1, Y: 0, Z: 1}: 0, {X: 2, Y: 1, Z: 1}: 1}, read.Blocks)
+
+	_, err = schematic.Read(bytes.NewReader(buf.Bytes()), mustRegistry(t, blocks.Type{ID: 1, Name: "wood"}))
+	assert.ErrorContains(t, err, `"plank" is not in the registry`)
+
+	placed, err := read.Place(store.Vec3{X: 100, Y: 0, Z: 100}, 90)
+	require.NoError(t, err)
+	assert.Equal(t, map[store.Vec3]int{{X: 101, Y: 0, Z: 100}: 2, {X: 100, Y: 0, Z: 101}: 0, {X: 100, Y: 1, Z: 102}: 1}, placed)
+	_, err = read.Place(store.Vec3{}, 30)
+	assert.Error(t, err)
+}
+
+func mustRegistry(t *testing.T, types ...blocks.Type) *blocks.Registry {
+	r, err := blocks.New(types)
+	require.NoError(t, err)
+	return r
+}
@@ -4,15 +4,22 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/perlinson/gocraft-server/internal/proto/auth"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
+	"github.com/perlinson/gocraft-server/proto/auth"
 	Store "github.com/perlinson/gocraft-server/internal/store"
 	"golang.org/x/crypto/bcrypt"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type AuthService struct {
@@ -21,8 +28,29 @@ type AuthService struct {
 	sessions map[string]*UserSession
 	store    *Store.Store
 	jwtKey   []byte // 添加 JWT 密钥
+	tokenTTL time.Duration
+
+	limiter *ratelimit.Limiter
+	// 连续登录失败 maxFailedLogins 次后锁定账号 lockout 时长
+	maxFailedLogins int
+	lockout         time.Duration
+	failures        map[string]*loginFailures
+
+	// admit 在密码正确后和注册前决定是否允许登录，如封禁和白名单
+	admit func(ctx context.Context, user *Store.User) error
+}
+
+type loginFailures struct {
+	count       int
+	last        time.Time
+	lockedUntil time.Time
 }
 
+// ContextUserID 是 RequireAuth 写入 gin 上下文的用户 ID 键
+const ContextUserID = "userID"
+
+const contextToken = "token"
+
 type UserSession struct {
 	UserID    string
 	Token     string
@@ -51,9 +79,49 @@ func NewAuthService(store *Store.Store) *AuthService {
 	return &AuthService{
 		sessions: make(map[string]*UserSession),
 		store:    store,
+		tokenTTL: 24 * time.Hour,
+
+		maxFailedLogins: 5,
+		lockout:         15 * time.Minute,
+		failures:        make(map[string]*loginFailures),
 	}
 }
 
+// SetTokenTTL 设置令牌有效期
+func (s *AuthService) SetTokenTTL(ttl time.Duration) {
+	s.tokenTTL = ttl
+}
+
+// SetRateLimiter 设置 HTTP 登录接口的限流器
+func (s *AuthService) SetRateLimiter(l *ratelimit.Limiter) {
+	s.limiter = l
+}
+
+// SetLockout 设置连续登录失败多少次后锁定账号及锁定时长
+func (s *AuthService) SetLockout(maxFailed int, d time.Duration) {
+	s.maxFailedLogins = maxFailed
+	s.lockout = d
+}
+
+// SetAdmission 设置登录和注册的准入检查，admit 返回错误时拒绝
+func (s *AuthService) SetAdmission(admit func(ctx context.Context, user *Store.User) error) {
+	s.admit = admit
+}
+
+// RevokeUser 使用户 userID 的所有令牌失效，返回失效的个数
+func (s *AuthService) RevokeUser(userID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for token, session := range s.sessions {
+		if session.UserID == userID {
+			delete(s.sessions, token)
+			n++
+		}
+	}
+	return n
+}
+
 // 生成随机令牌
 func generateToken() (string, error) {
 	b := make([]byte, 32)
@@ -66,8 +134,29 @@ func generateToken() (string, error) {
 
 // 登录实现
 func (s *AuthService) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
-	// TODO: 实际用户验证逻辑
-	userID := "user123" // 示例用户ID
+	if err := s.checkLockout(req.Username); err != nil {
+		return nil, err
+	}
+
+	// 校验用户名与密码
+	stored, err := s.store.GetUser(ctx, req.Username)
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up user failed", "username", req.Username, "error", err)
+		return nil, status.Error(codes.Internal, "login failed")
+	}
+	if stored == nil || bcrypt.CompareHashAndPassword([]byte(stored.Password), []byte(req.Password)) != nil {
+		s.loginFailed(ctx, req.Username)
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+	s.mu.Lock()
+	delete(s.failures, req.Username)
+	s.mu.Unlock()
+	if s.admit != nil {
+		if err := s.admit(ctx, stored); err != nil {
+			return nil, err
+		}
+	}
+	userID := strconv.Itoa(int(stored.ID))
 
 	// 生成会话令牌
 	token, err := generateToken()
@@ -79,17 +168,17 @@ func (s *AuthService) Login(ctx context.Context, req *auth.LoginRequest) (*auth.
 	session := &UserSession{
 		UserID:    userID,
 		Token:     token,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		ExpiresAt: time.Now().Add(s.tokenTTL),
 	}
 
 	s.mu.Lock()
 	s.sessions[token] = session
 	s.mu.Unlock()
 
-	expiresTime := time.Now().Add(24 * time.Hour)
+	expiresTime := time.Now().Add(s.tokenTTL)
 	user := &auth.User{
 		Id:   userID,
-		Name: "测试用户",
+		Name: stored.Username,
 	}
 	return &auth.LoginResponse{
 		Token:   token,
@@ -98,6 +187,68 @@ func (s *AuthService) Login(ctx context.Context, req *auth.LoginRequest) (*auth.
 	}, nil
 }
 
+// checkLockout 账号锁定期间拒绝登录
+func (s *AuthService) checkLockout(username string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := s.failures[username]
+	if !ok {
+		return nil
+	}
+	if remaining := time.Until(f.lockedUntil); remaining > 0 {
+		return ratelimit.Exhausted("account locked after repeated failed logins", remaining)
+	}
+	return nil
+}
+
+// loginFailed 记录一次失败的登录，达到上限时锁定账号
+func (s *AuthService) loginFailed(ctx context.Context, username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	f, ok := s.failures[username]
+	if !ok {
+		f = &loginFailures{}
+		s.failures[username] = f
+	}
+	// 只计算 lockout 时长内连续的失败
+	if now.Sub(f.last) > s.lockout {
+		f.count = 0
+	}
+	f.last = now
+	f.count++
+	if f.count >= s.maxFailedLogins {
+		f.count = 0
+		f.lockedUntil = now.Add(s.lockout)
+		slog.WarnContext(ctx, "account locked after repeated failed logins", "username", username, "duration", s.lockout)
+	}
+}
+
+// PruneFailures 每隔 interval 删除锁定已结束、lockout 时长内没有再失败的记录，直到 ctx 结束。
+// 任何用户名都会留下记录，包括不存在的，不清理会无限增长
+func (s *AuthService) PruneFailures(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.pruneFailures(now)
+		}
+	}
+}
+
+func (s *AuthService) pruneFailures(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for username, f := range s.failures {
+		if now.After(f.lockedUntil) && now.Sub(f.last) > s.lockout {
+			delete(s.failures, username)
+		}
+	}
+}
+
 // 登出实现
 func (s *AuthService) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
 	s.mu.Lock()
@@ -114,33 +265,32 @@ func (s *AuthService) Register(ctx context.Context, req *RegisterRequest) (*Regi
 		return nil, err
 	}
 	if exists {
-		return nil, err
+		return nil, status.Errorf(codes.AlreadyExists, "username %s is taken", req.Username)
 	}
-
-	// 2. 密码加密
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
-	if err != nil {
-		return nil, err
+	// 注册与登录一样经过准入检查，否则被封禁的 IP 和白名单外的用户可以注册新账号
+	if s.admit != nil {
+		if err := s.admit(ctx, &Store.User{Username: req.Username, Role: Store.DefaultRole}); err != nil {
+			return nil, err
+		}
 	}
 
-	// 3. 创建用户
-	userID := uuid.New().String()
-
-	// 4. 保存到数据库
-	if err := s.store.CreateUser(ctx, req.Username, string(hashedPassword), req.Email); err != nil {
+	// 2. 创建用户并保存到数据库，密码由 store 加密
+	stored, err := s.store.CreateUser(ctx, req.Username, req.Password, req.Email)
+	if err != nil {
 		return nil, err
 	}
+	userID := strconv.Itoa(int(stored.ID))
 
-	// 5. 生成 token
+	// 3. 生成 token
 	token := uuid.New().String()
-	expires := time.Now().Add(24 * time.Hour).Unix()
+	expires := time.Now().Add(s.tokenTTL).Unix()
 
-	// 6. 保存会话
+	// 4. 保存会话
 	s.mu.Lock()
 	s.sessions[token] = &UserSession{
 		UserID:    userID,
 		Token:     token,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		ExpiresAt: time.Now().Add(s.tokenTTL),
 	}
 	s.mu.Unlock()
 
@@ -158,9 +308,77 @@ func (s *AuthService) Register(ctx context.Context, req *RegisterRequest) (*Regi
 // RegisterRoutes 注册 HTTP 路由
 func (s *AuthService) RegisterRoutes(r *gin.Engine) {
 	// 登录路由
-	r.POST("/api/auth/login", s.httpLogin)
+	r.POST("/api/auth/login", s.limiter.Middleware(ratelimit.Login), s.httpLogin)
 	// 注册路由
-	r.POST("/api/auth/register", s.httpRegister)
+	r.POST("/api/auth/register", s.limiter.Middleware(ratelimit.Login), s.httpRegister)
+	// 登出路由
+	r.POST("/api/auth/logout", s.RequireAuth(), s.httpLogout)
+}
+
+// RegisterAdminRoutes 注册管理路由，auth 限定为管理员
+func (s *AuthService) RegisterAdminRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	r.PUT("/admin/users/:username/role", auth, s.httpSetRole)
+}
+
+// httpSetRole 修改用户角色，角色决定区域编辑的方块上限
+func (s *AuthService) httpSetRole(c *gin.Context) {
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := c.BindJSON(&req); err != nil || req.Role == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	username := c.Param("username")
+	found, err := s.store.SetUserRole(c.Request.Context(), username, req.Role)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "setting user role failed", "username", username, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "setting role failed"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "user role changed", "username", username, "role", req.Role)
+	c.JSON(http.StatusOK, gin.H{"username": username, "role": req.Role})
+}
+
+// ResolveUser 返回令牌对应的用户 ID，供 gRPC 拦截器使用
+func (s *AuthService) ResolveUser(token string) (string, bool) {
+	session, ok := s.ValidateToken(token)
+	if !ok {
+		return "", false
+	}
+	return session.UserID, true
+}
+
+// ValidateToken 校验令牌，返回未过期的会话
+func (s *AuthService) ValidateToken(token string) (*UserSession, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	session, ok := s.sessions[token]
+	if !ok || time.Now().After(session.ExpiresAt) {
+		return nil, false
+	}
+	return session, true
+}
+
+// RequireAuth 要求请求携带有效的 Bearer 令牌，并把用户 ID 写入上下文
+func (s *AuthService) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		session, ok := s.ValidateToken(token)
+		if token == "" || !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			return
+		}
+		c.Set(ContextUserID, session.UserID)
+		c.Set(contextToken, session.Token)
+		c.Request = c.Request.WithContext(logging.WithUserID(c.Request.Context(), session.UserID))
+		c.Next()
+	}
 }
 
 // httpLogin 处理登录请求
@@ -171,9 +389,20 @@ func (s *AuthService) httpLogin(c *gin.Context) {
 		return
 	}
 
-	resp, err := s.Login(context.Background(), &req)
+	resp, err := s.Login(logging.WithClientIP(c.Request.Context(), c.ClientIP()), &req)
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// httpLogout 处理登出请求
+func (s *AuthService) httpLogout(c *gin.Context) {
+	resp, err := s.Logout(context.Background(), &auth.LogoutRequest{Token: c.GetString(contextToken)})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "login failed"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "logout failed"})
 		return
 	}
 
@@ -188,8 +417,12 @@ func (s *AuthService) httpRegister(c *gin.Context) {
 		return
 	}
 
-	resp, err := s.Register(context.Background(), &req)
+	resp, err := s.Register(logging.WithClientIP(c.Request.Context(), c.ClientIP()), &req)
 	if err != nil {
+		if code := status.Code(err); code == codes.AlreadyExists || code == codes.PermissionDenied {
+			httpError(c, err)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "register failed"})
 		return
 	}
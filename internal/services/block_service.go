@@ -2,134 +2,447 @@ package services
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
 	"sync"
-	"time"
 
-	blockpb "github.com/perlinson/gocraft-server/internal/proto/block"
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/blocks"
+	"github.com/perlinson/gocraft-server/internal/chunkcache"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/metrics"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
+	"github.com/perlinson/gocraft-server/internal/worlds"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
 	Store "github.com/perlinson/gocraft-server/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// maxBatchBlocks 是一次 UpdateBlocks 最多修改的方块数，避免单个事务过长
+const maxBatchBlocks = 65536
+
+// blockStore 读写方块和区块版本，Store.Store 直接读写数据库，
+// chunkcache.Cache 在内存中修改并定期写入数据库
+type blockStore interface {
+	UpdateBlock(world string, id Store.Vec3, w int, version string, cond Store.Condition) ([]Store.Vec3, error)
+	UpdateBlocks(world string, blocks map[Store.Vec3]int, version string) ([]Store.Vec3, error)
+	GetBlock(world string, id Store.Vec3) (int, error)
+	GetChunkVersion(world string, id Store.Vec3) string
+	RangeBlocks(world string, id Store.Vec3, f func(bid Store.Vec3, w int, version string)) error
+	RangeRegion(world string, min, max Store.Vec3, f func(bid Store.Vec3, w int)) error
+}
+
 type BlockService struct {
 	blockpb.UnimplementedBlockServiceServer
-	mu      sync.RWMutex
-	chunks  map[string]*ChunkData
-	version int64
+	locks   chunkLocks
 	store   *Store.Store
-}
+	chunks  blockStore
+	limiter *ratelimit.Limiter
+	blocks  *blocks.Registry
+	worlds  *worlds.Registry
+	// limits 是每个角色一次 UpdateBlocks 最多修改的方块数，与区域编辑相同，nil 时不限制
+	limits map[string]int
 
-type ChunkData struct {
-	blocks  []int32
-	version int64
+	// StreamChunk 和 StreamView 订阅者，按区块分组
+	subsMu sync.Mutex
+	subs   map[chunkID]map[chan *blockpb.ChunkUpdate]struct{}
+
+	// StreamView 的视野，按 id
+	viewsMu sync.Mutex
+	views   map[string]*chunkView
 }
 
 func NewBlockService(store *Store.Store) *BlockService {
 	return &BlockService{
 		store:  store,
-		chunks: make(map[string]*ChunkData),
+		chunks: store,
+		blocks: blocks.Default(),
+		worlds: worlds.Default(),
+		subs:   make(map[chunkID]map[chan *blockpb.ChunkUpdate]struct{}),
+		views:  make(map[string]*chunkView),
 	}
 }
 
-// 区块坐标转字符串键
-func chunkKey(p, q int32) string {
-	return fmt.Sprintf("%d:%d", p, q)
+// SetBlockRegistry 设置允许放置的方块类型，默认为 gocraft 客户端的方块
+func (s *BlockService) SetBlockRegistry(r *blocks.Registry) {
+	s.blocks = r
 }
 
-// 实现 FetchChunk RPC
-func (s *BlockService) FetchChunk(ctx context.Context, req *blockpb.FetchChunkRequest) (*blockpb.FetchChunkResponse, error) {
-	id := Store.Vec3{X: req.P, Y: 0, Z: req.Q}
+// SetChunkCache 让方块读写经过区块缓存，修改由缓存定期写入数据库
+func (s *BlockService) SetChunkCache(c *chunkcache.Cache) {
+	s.chunks = c
+}
 
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// SetWorlds 设置服务端的世界，默认只有默认世界
+func (s *BlockService) SetWorlds(r *worlds.Registry) {
+	s.worlds = r
+}
 
-	version := s.store.GetChunkVersion(id)
+// SetRateLimiter 设置 HTTP 方块修改接口的限流器，UpdateBlocks 也用它按方块数计费
+func (s *BlockService) SetRateLimiter(l *ratelimit.Limiter) {
+	s.limiter = l
+}
 
-	response := &blockpb.FetchChunkResponse{
-		Version: version,
+// SetEditLimits 设置每个角色一次 UpdateBlocks 最多修改的方块数，即区域编辑的 edit.limits。
+// 未登录的调用者按默认角色计算，没有上限的角色不能批量修改
+func (s *BlockService) SetEditLimits(limits map[string]int) {
+	s.limits = limits
+}
+
+// 实现 FetchChunk RPC
+func (s *BlockService) FetchChunk(ctx context.Context, req *blockpb.FetchChunkRequest) (*blockpb.FetchChunkResponse, error) {
+	world, err := s.World(ctx, req.World, false)
+	if err != nil {
+		return nil, err
 	}
+	id := Store.Vec3{X: req.P, Y: 0, Z: req.Q}
 
-	if req.Version == version {
-		return response, nil
+	if req.Version == s.chunks.GetChunkVersion(world.Name, id) {
+		metrics.ChunkFetches.WithLabelValues("hit").Inc()
+		return &blockpb.FetchChunkResponse{Version: req.Version}, nil
+	}
+	metrics.ChunkFetches.WithLabelValues("miss").Inc()
+	version, blocks, err := s.readChunk(world.Name, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "reading chunk failed", "world", world.Name, "p", req.P, "q", req.Q, "error", err)
+		return nil, status.Error(codes.Internal, "reading chunk failed")
 	}
+	return &blockpb.FetchChunkResponse{Version: version, Blocks: blocks}, nil
+}
+
+// readChunk 返回区块 id 的版本和存储的方块，读锁保证两者来自同一次修改
+func (s *BlockService) readChunk(world string, id Store.Vec3) (string, []*blockpb.Block, error) {
+	defer s.locks.rlock(world, id)()
+
+	version := s.chunks.GetChunkVersion(world, id)
 	blocks := make([]*blockpb.Block, 0)
-	s.store.RangeBlocks(id, func(bid Store.Vec3, w int) {
+	err := s.chunks.RangeBlocks(world, id, func(bid Store.Vec3, w int, version string) {
 		blocks = append(blocks, &blockpb.Block{
-			X: bid.X,
-			Y: bid.Y,
-			Z: bid.Z,
-			W: int32(w),
+			X:       bid.X,
+			Y:       bid.Y,
+			Z:       bid.Z,
+			W:       int32(w),
+			Version: version,
 		})
 	})
-
-	response.Blocks = blocks
-	return response, nil
+	if err != nil {
+		return "", nil, err
+	}
+	return version, blocks, nil
 }
 
 // 实现 UpdateBlock RPC
 func (s *BlockService) UpdateBlock(ctx context.Context, req *blockpb.UpdateBlockRequest) (*blockpb.UpdateBlockResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	if err := s.CheckBlockType(req.W); err != nil {
+		return nil, err
+	}
+	world, err := s.World(ctx, req.World, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// 区块由服务端根据方块坐标计算，客户端给出的区块必须一致
+	id := Store.Vec3{X: req.X, Y: req.Y, Z: req.Z}
+	if cid := id.Chunkid(); cid.X != req.P || cid.Z != req.Q {
+		return nil, status.Errorf(codes.InvalidArgument, "block (%d, %d, %d) is in chunk (%d, %d), not (%d, %d)",
+			req.X, req.Y, req.Z, cid.X, cid.Z, req.P, req.Q)
+	}
 
-	log.Printf("UpdateBlock: %v", req)
+	// 锁住方块所在区块和相邻的受影响区块，其他区块的修改可以并行
+	chunks := id.AffectedChunks()
+	defer s.locks.lock(world.Name, chunks)()
+
+	slog.DebugContext(ctx, "update block", "world", world.Name, "p", req.P, "q", req.Q, "x", req.X, "y", req.Y, "z", req.Z, "w", req.W)
 	version := Store.GenerateChunkVersion()
 
-	// 更新方块和区块版本
-	s.store.UpdateBlock(Store.Vec3{X: req.X, Y: req.Y, Z: req.Z}, int(req.W))
-	s.store.UpdateChunkVersion(Store.Vec3{X: req.P, Y: 0, Z: req.Q}, version)
+	// 条件更新：版本不一致时返回当前状态，由客户端合并
+	var cond Store.Condition
+	switch req.Precondition {
+	case blockpb.UpdateBlockRequest_CHUNK:
+		cond.ChunkVersion = &req.Version
+	case blockpb.UpdateBlockRequest_BLOCK:
+		cond.BlockVersion = &req.Version
+	}
 
-	// 创建响应
+	// 在同一事务中更新方块和所有受影响区块的版本
+	chunks, err = s.chunks.UpdateBlock(world.Name, id, int(req.W), version, cond)
+	var conflict *Store.ConflictError
+	if errors.As(err, &conflict) {
+		return nil, conflictError(conflict)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "storing block failed", "x", req.X, "y", req.Y, "z", req.Z, "error", err)
+		return nil, status.Error(codes.Internal, "storing block failed")
+	}
+	metrics.BlockUpdates.Inc()
+
+	// 创建响应，chunks[0] 是方块所在区块
 	response := &blockpb.UpdateBlockResponse{
 		Version: version,
 	}
+	for _, c := range chunks[1:] {
+		response.Neighbors = append(response.Neighbors, &blockpb.ChunkID{P: c.X, Q: c.Z})
+	}
 
-	// TODO: 广播更新给其他玩家的逻辑需要重新设计
-	// 可能需要使用某种发布订阅机制或事件系统
+	// 广播给订阅了这些区块的玩家
+	s.broadcast(world.Name, chunkUpdates(map[Store.Vec3]int{id: int(req.W)}, chunks, version))
+	return response, nil
+}
 
+// UpdateBlocks 在一个事务中应用一批方块修改，每个受影响的区块只更新一次版本、广播一次
+func (s *BlockService) UpdateBlocks(ctx context.Context, req *blockpb.UpdateBlocksRequest) (*blockpb.UpdateBlocksResponse, error) {
+	if len(req.Blocks) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no blocks to update")
+	}
+	if len(req.Blocks) > maxBatchBlocks {
+		return nil, status.Errorf(codes.InvalidArgument, "%d blocks exceed the batch limit of %d", len(req.Blocks), maxBatchBlocks)
+	}
+	changes := make(map[Store.Vec3]int, len(req.Blocks))
+	for _, b := range req.Blocks {
+		if err := s.CheckBlockType(b.W); err != nil {
+			return nil, err
+		}
+		changes[Store.Vec3{X: b.X, Y: b.Y, Z: b.Z}] = int(b.W)
+	}
+	world, err := s.World(ctx, req.World, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBatch(ctx, len(req.Blocks)); err != nil {
+		return nil, err
+	}
+
+	version, chunks, err := s.ApplyBlocks(ctx, world.Name, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &blockpb.UpdateBlocksResponse{
+		Version: version,
+	}
+	for _, c := range chunks {
+		response.Chunks = append(response.Chunks, &blockpb.ChunkID{P: c.X, Q: c.Z})
+	}
 	return response, nil
 }
 
+// checkBatch 检查调用者的角色能否一次修改 n 个方块，并从 BatchBlocks 预算中扣除 n 个令牌
+func (s *BlockService) checkBatch(ctx context.Context, n int) error {
+	if s.limits != nil {
+		role, err := userRole(ctx, s.store)
+		if err != nil {
+			return err
+		}
+		if role == "" {
+			role = Store.DefaultRole
+		}
+		limit, ok := s.limits[role]
+		if !ok {
+			return status.Errorf(codes.PermissionDenied, "role %q cannot update blocks in batches", role)
+		}
+		if err := checkLimit(int64(n), limit); err != nil {
+			return err
+		}
+	}
+	return s.limiter.CheckN(ratelimit.BatchBlocks, n, ratelimit.ClientKeys(ctx, logging.ClientIP(ctx))...)
+}
+
+// ApplyBlocks 在一个事务中把 changes 写入世界 world 并广播，不检查方块类型和权限。
+// Store.Unset 删除存储的方块，恢复生成的地形。返回新版本和受影响的区块
+func (s *BlockService) ApplyBlocks(ctx context.Context, world string, changes map[Store.Vec3]int) (string, []Store.Vec3, error) {
+	defer s.locks.lock(world, Store.AffectedChunksOf(changes))()
+
+	slog.DebugContext(ctx, "update blocks", "world", world, "blocks", len(changes))
+	version := Store.GenerateChunkVersion()
+	chunks, err := s.chunks.UpdateBlocks(world, changes, version)
+	if err != nil {
+		slog.ErrorContext(ctx, "storing blocks failed", "blocks", len(changes), "error", err)
+		return "", nil, status.Error(codes.Internal, "storing blocks failed")
+	}
+	metrics.BlockUpdates.Add(float64(len(changes)))
+
+	s.broadcast(world, chunkUpdates(changes, chunks, version))
+	return version, chunks, nil
+}
+
+// World 返回名为 name 的世界，空名为默认世界。build 为真时要求调用者的角色
+// 可以修改方块，否则要求可以进入
+func (s *BlockService) World(ctx context.Context, name string, build bool) (*worlds.World, error) {
+	if build {
+		return worldAccess(ctx, s.worlds, s.store, name, (*worlds.World).CanBuild, "build")
+	}
+	return worldAccess(ctx, s.worlds, s.store, name, (*worlds.World).CanEnter, "enter")
+}
+
+// conflictError 把版本冲突转换为带当前状态的 Aborted 错误
+func conflictError(conflict *Store.ConflictError) error {
+	st := status.New(codes.Aborted, conflict.Error())
+	b := conflict.Block
+	detail := &blockpb.UpdateConflict{
+		ChunkVersion: conflict.ChunkVersion,
+		Block:        &blockpb.Block{X: b.BlockX, Y: b.BlockY, Z: b.BlockZ, W: b.BlockType, Version: b.Version},
+	}
+	if withDetail, err := st.WithDetails(detail); err == nil {
+		st = withDetail
+	}
+	return st.Err()
+}
+
+// CheckBlockType 拒绝未注册或玩家不可放置的方块，0 表示挖掉方块
+func (s *BlockService) CheckBlockType(w int32) error {
+	if w == blocks.Air {
+		return nil
+	}
+	t, ok := s.blocks.Lookup(w)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown block type %d", w)
+	}
+	if !t.Placeable {
+		return status.Errorf(codes.PermissionDenied, "block type %s cannot be placed", t.Name)
+	}
+	return nil
+}
+
+// ListBlockTypes 返回方块注册表，客户端版本一致时不返回类型
+func (s *BlockService) ListBlockTypes(ctx context.Context, req *blockpb.ListBlockTypesRequest) (*blockpb.ListBlockTypesResponse, error) {
+	resp := &blockpb.ListBlockTypesResponse{
+		Version: s.blocks.Version(),
+	}
+	if req.Version == resp.Version {
+		return resp, nil
+	}
+	for _, t := range s.blocks.Types() {
+		resp.Types = append(resp.Types, &blockpb.BlockType{
+			Id:          t.ID,
+			Name:        t.Name,
+			Solid:       t.Solid,
+			Transparent: t.Transparent,
+			Light:       t.Light,
+			Hardness:    t.Hardness,
+			Placeable:   t.Placeable,
+		})
+	}
+	return resp, nil
+}
+
+// StreamChunk 推送世界 world 中区块 (p, q) 的更新，客户端缓存的版本已过期时先推送一次当前版本
 func (s *BlockService) StreamChunk(req *blockpb.ChunkRequest, stream blockpb.BlockService_StreamChunkServer) error {
-	// 实现流区块数据的逻辑
-	// 创建一个定时器用于定期发送区块更新
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
+	metrics.ChunkStreamSubscribers.Inc()
+	defer metrics.ChunkStreamSubscribers.Dec()
 
-	// 记录最后发送的版本号
-	lastVersion := int64(0)
+	world, err := s.World(stream.Context(), req.World, false)
+	if err != nil {
+		return err
+	}
+	id := chunkID{world.Name, req.P, req.Q}
+	ch := make(chan *blockpb.ChunkUpdate, 64)
+	s.subscribe(id, ch)
+	defer s.unsubscribe(id, ch)
+
+	if version := s.chunks.GetChunkVersion(world.Name, Store.Vec3{X: req.P, Y: 0, Z: req.Q}); version != req.Version {
+		if err := stream.Send(&blockpb.ChunkUpdate{P: req.P, Q: req.Q, Version: version}); err != nil {
+			return err
+		}
+	}
 
 	for {
 		select {
 		case <-stream.Context().Done():
 			// 如果客户端断开连接，退出循环
 			return stream.Context().Err()
-		case <-ticker.C:
-			s.mu.RLock()
-			// 检查是否有新的区块更新
-			if s.version > lastVersion {
-				// 遍历所有区块
-				for key, chunk := range s.chunks {
-					if chunk.version > lastVersion {
-						// 解析区块坐标
-						var p, q int32
-						fmt.Sscanf(key, "%d:%d", &p, &q)
-
-						// 发送更新的区块数据
-						response := &blockpb.ChunkUpdate{
-							P:       p,
-							Q:       q,
-							Blocks:  chunk.blocks,
-							Version: fmt.Sprintf("%d", chunk.version),
-						}
-						if err := stream.Send(response); err != nil {
-							s.mu.RUnlock()
-							return err
-						}
-					}
-				}
-				lastVersion = s.version
+		case update := <-ch:
+			if err := stream.Send(update); err != nil {
+				return err
 			}
-			s.mu.RUnlock()
 		}
 	}
 }
+
+// RegisterRoutes 注册 HTTP 路由，修改方块需要登录。查询区块没有登录，
+// 只能查询所有角色都可以进入的世界
+func (s *BlockService) RegisterRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	r.GET("/api/chunks/:p/:q", s.httpFetchChunk)
+	r.GET("/api/block-types", s.httpListBlockTypes)
+	r.POST("/api/blocks", auth, s.limiter.Middleware(ratelimit.BlockUpdates), s.httpUpdateBlock)
+	r.POST("/api/blocks/batch", auth, s.limiter.Middleware(ratelimit.BlockBatches), s.httpUpdateBlocks)
+}
+
+// httpListBlockTypes 返回方块注册表，?version= 与缓存版本相同时不返回类型
+func (s *BlockService) httpListBlockTypes(c *gin.Context) {
+	resp, err := s.ListBlockTypes(c.Request.Context(), &blockpb.ListBlockTypesRequest{Version: c.Query("version")})
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// httpUpdateBlocks 处理批量方块修改
+func (s *BlockService) httpUpdateBlocks(c *gin.Context) {
+	var req blockpb.UpdateBlocksRequest
+	if err := bindProto(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	resp, err := s.UpdateBlocks(logging.WithClientIP(c.Request.Context(), c.ClientIP()), &req)
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+
+	renderProto(c, http.StatusOK, resp)
+}
+
+// httpFetchChunk 处理区块查询，?world= 选择世界，?version= 与缓存版本相同时不返回方块
+func (s *BlockService) httpFetchChunk(c *gin.Context) {
+	p, perr := strconv.ParseInt(c.Param("p"), 10, 32)
+	q, qerr := strconv.ParseInt(c.Param("q"), 10, 32)
+	if perr != nil || qerr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chunk coordinates"})
+		return
+	}
+
+	resp, err := s.FetchChunk(c.Request.Context(), &blockpb.FetchChunkRequest{
+		P:       int32(p),
+		Q:       int32(q),
+		Version: c.Query("version"),
+		World:   c.Query("world"),
+	})
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+
+	renderProto(c, http.StatusOK, resp)
+}
+
+// httpUpdateBlock 处理方块修改
+func (s *BlockService) httpUpdateBlock(c *gin.Context) {
+	var req blockpb.UpdateBlockRequest
+	if err := bindProto(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	resp, err := s.UpdateBlock(c.Request.Context(), &req)
+	if err != nil {
+		// 冲突时同时返回当前状态
+		for _, detail := range status.Convert(err).Details() {
+			if conflict, ok := detail.(*blockpb.UpdateConflict); ok {
+				data, _ := protojson.Marshal(conflict)
+				c.JSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message(), "conflict": json.RawMessage(data)})
+				return
+			}
+		}
+		httpError(c, err)
+		return
+	}
+
+	renderProto(c, http.StatusOK, resp)
+}
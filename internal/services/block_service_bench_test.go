@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+)
+
+// slowStore 在内存中保存方块，每次读写等待 latency，模拟数据库的往返时间
+type slowStore struct {
+	latency time.Duration
+	mu      sync.Mutex
+	blocks  map[Store.Vec3]int
+}
+
+func (s *slowStore) UpdateBlock(world string, id Store.Vec3, w int, version string, cond Store.Condition) ([]Store.Vec3, error) {
+	time.Sleep(s.latency)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocks[id] = w
+	return id.AffectedChunks(), nil
+}
+
+func (s *slowStore) UpdateBlocks(world string, blocks map[Store.Vec3]int, version string) ([]Store.Vec3, error) {
+	time.Sleep(s.latency)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, w := range blocks {
+		s.blocks[id] = w
+	}
+	return Store.AffectedChunksOf(blocks), nil
+}
+
+func (s *slowStore) GetBlock(world string, id Store.Vec3) (int, error) {
+	time.Sleep(s.latency)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.blocks[id], nil
+}
+
+func (s *slowStore) GetChunkVersion(world string, id Store.Vec3) string {
+	time.Sleep(s.latency)
+	return ""
+}
+
+func (s *slowStore) RangeBlocks(world string, id Store.Vec3, f func(bid Store.Vec3, w int, version string)) error {
+	time.Sleep(s.latency)
+	return nil
+}
+
+func (s *slowStore) RangeRegion(world string, min, max Store.Vec3, f func(bid Store.Vec3, w int)) error {
+	time.Sleep(s.latency)
+	return nil
+}
+
+// benchmarkEditors 让 64 个编辑者并发修改方块，spread 为真时每个编辑者在自己的区块中修改，
+// 否则都修改同一个区块。报告每秒修改的方块数
+func benchmarkEditors(b *testing.B, spread bool) {
+	s := NewBlockService(nil)
+	s.chunks = &slowStore{latency: time.Millisecond, blocks: make(map[Store.Vec3]int)}
+	var editors atomic.Int32
+	b.SetParallelism(64)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		// 区块中间的方块只影响一个区块
+		x := int32(16)
+		if spread {
+			x += editors.Add(1) * 2 * Store.ChunkWidth
+		}
+		for y := int32(0); pb.Next(); y++ {
+			_, err := s.UpdateBlock(context.Background(), &blockpb.UpdateBlockRequest{
+				X: x, Y: y % 256, Z: 16, W: 1, P: x / Store.ChunkWidth, Q: 16 / Store.ChunkWidth,
+			})
+			if err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "blocks/s")
+}
+
+func BenchmarkUpdateBlockSeparateChunks(b *testing.B) { benchmarkEditors(b, true) }
+
+func BenchmarkUpdateBlockSameChunk(b *testing.B) { benchmarkEditors(b, false) }
@@ -0,0 +1,307 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 测试方块类型校验与注册表下载
+func TestBlockServiceBlockTypes(t *testing.T) {
+	ctx := context.Background()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	blockService := services.NewBlockService(s)
+
+	update := func(w int32) error {
+		_, err := blockService.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{X: 1, Y: 2, Z: 3, W: w})
+		return err
+	}
+	assert.NoError(t, update(3))
+	assert.NoError(t, update(0))
+	assert.Equal(t, codes.InvalidArgument, status.Code(update(999)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(update(16)))
+
+	resp, err := blockService.ListBlockTypes(ctx, &blockpb.ListBlockTypesRequest{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Version)
+	assert.Equal(t, "grass", resp.Types[0].Name)
+
+	resp, err = blockService.ListBlockTypes(ctx, &blockpb.ListBlockTypesRequest{Version: resp.Version})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Types)
+}
+
+// 测试区块坐标校验以及边界方块同时更新相邻区块版本
+func TestBlockServiceChunkConsistency(t *testing.T) {
+	ctx := context.Background()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	blockService := services.NewBlockService(s)
+
+	_, err = blockService.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{P: 1, Q: 0, X: 5, Y: 10, Z: 5, W: 3})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// (-1, 10, 31) is on the corner of chunk (-1, 0) next to (0, 0) and (-1, 1)
+	resp, err := blockService.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{P: -1, Q: 0, X: -1, Y: 10, Z: 31, W: 3})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*blockpb.ChunkID{{P: 0, Q: 0}, {P: -1, Q: 1}}, resp.Neighbors)
+	for _, c := range [][2]int32{{-1, 0}, {0, 0}, {-1, 1}} {
+		assert.Equal(t, resp.Version, s.GetChunkVersion(store.DefaultWorld, store.Vec3{X: c[0], Z: c[1]}))
+	}
+
+	// the block is stored once, updates replace it
+	_, err = blockService.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{P: -1, Q: 0, X: -1, Y: 10, Z: 31, W: 4})
+	require.NoError(t, err)
+	chunk, err := blockService.FetchChunk(ctx, &blockpb.FetchChunkRequest{P: -1, Q: 0})
+	require.NoError(t, err)
+	require.Len(t, chunk.Blocks, 1)
+	assert.Equal(t, int32(4), chunk.Blocks[0].W)
+}
+
+// 测试条件更新：版本过期时返回 Aborted 与当前状态
+func TestBlockServiceConditionalUpdate(t *testing.T) {
+	ctx := context.Background()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	blockService := services.NewBlockService(s)
+
+	update := func(precondition blockpb.UpdateBlockRequest_Precondition, version string, x, w int32) (*blockpb.UpdateBlockResponse, error) {
+		return blockService.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{X: x, Y: 10, Z: 5, W: w, Version: version, Precondition: precondition})
+	}
+	conflict := func(_ *blockpb.UpdateBlockResponse, err error) *blockpb.UpdateConflict {
+		require.Equal(t, codes.Aborted, status.Code(err))
+		for _, detail := range status.Convert(err).Details() {
+			if c, ok := detail.(*blockpb.UpdateConflict); ok {
+				return c
+			}
+		}
+		t.Fatal("no UpdateConflict detail")
+		return nil
+	}
+
+	// a block that was never written has the empty version
+	first, err := update(blockpb.UpdateBlockRequest_BLOCK, "", 5, 3)
+	require.NoError(t, err)
+
+	// another block of the chunk moves the chunk version on
+	second, err := update(blockpb.UpdateBlockRequest_NONE, "stale", 6, 3)
+	require.NoError(t, err)
+
+	c := conflict(update(blockpb.UpdateBlockRequest_CHUNK, first.Version, 5, 4))
+	assert.Equal(t, second.Version, c.ChunkVersion)
+	assert.Equal(t, int32(3), c.Block.W)
+	assert.Equal(t, first.Version, c.Block.Version)
+
+	// the block itself is unchanged, so a block precondition holds
+	_, err = update(blockpb.UpdateBlockRequest_BLOCK, first.Version, 5, 4)
+	require.NoError(t, err)
+	c = conflict(update(blockpb.UpdateBlockRequest_BLOCK, first.Version, 5, 7))
+	assert.Equal(t, int32(4), c.Block.W)
+
+	_, err = update(blockpb.UpdateBlockRequest_CHUNK, c.ChunkVersion, 5, 7)
+	assert.NoError(t, err)
+}
+
+type chunkStream struct {
+	blockpb.BlockService_StreamChunkServer
+	ctx     context.Context
+	updates chan *blockpb.ChunkUpdate
+}
+
+func (s *chunkStream) Context() context.Context { return s.ctx }
+
+func (s *chunkStream) Send(update *blockpb.ChunkUpdate) error {
+	s.updates <- update
+	return nil
+}
+
+// 测试批量修改在一个版本内生效，并且每个区块只广播一次
+func TestBlockServiceUpdateBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	blockService := services.NewBlockService(s)
+
+	stream := &chunkStream{ctx: ctx, updates: make(chan *blockpb.ChunkUpdate, 8)}
+	go blockService.StreamChunk(&blockpb.ChunkRequest{P: 0, Q: 0}, stream)
+	require.Eventually(t, func() bool { return blockService.ChunkSubscribers(store.DefaultWorld, 0, 0) == 1 }, time.Second, time.Millisecond)
+
+	_, err = blockService.UpdateBlocks(ctx, &blockpb.UpdateBlocksRequest{Blocks: []*blockpb.Block{{X: 1, W: 999}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// a wall along the border of chunks (0, 0) and (1, 0)
+	var wall []*blockpb.Block
+	for y := int32(0); y < 4; y++ {
+		wall = append(wall, &blockpb.Block{X: 31, Y: y, Z: 10, W: 3}, &blockpb.Block{X: 32, Y: y, Z: 10, W: 3})
+	}
+	resp, err := blockService.UpdateBlocks(ctx, &blockpb.UpdateBlocksRequest{Blocks: wall})
+	require.NoError(t, err)
+	assert.Equal(t, []*blockpb.ChunkID{{P: 0, Q: 0}, {P: 1, Q: 0}}, resp.Chunks)
+
+	update := <-stream.updates
+	assert.Equal(t, resp.Version, update.Version)
+	assert.Len(t, update.Blocks, 4*4)
+	select {
+	case extra := <-stream.updates:
+		t.Fatalf("unexpected second update %v", extra)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	for _, p := range []int32{0, 1} {
+		chunk, err := blockService.FetchChunk(ctx, &blockpb.FetchChunkRequest{P: p})
+		require.NoError(t, err)
+		assert.Equal(t, resp.Version, chunk.Version)
+		assert.Len(t, chunk.Blocks, 4)
+	}
+}
+
+// 测试批量修改受角色上限限制，并按方块数扣除 BlockUpdates 令牌
+func TestBlockServiceBatchLimits(t *testing.T) {
+	ctx := logging.WithClientIP(context.Background(), "10.0.0.1")
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	blockService := services.NewBlockService(s)
+	blockService.SetEditLimits(map[string]int{"player": 4})
+	blockService.SetRateLimiter(ratelimit.New(config.RateLimitConfig{BatchBlocks: config.Rate{PerSecond: 0.001, Burst: 6}}))
+	batch := batchUpdater(ctx, blockService)
+
+	// 未登录的调用者按默认角色计算
+	assert.Equal(t, codes.PermissionDenied, status.Code(batch(5)))
+	assert.NoError(t, batch(4))
+	err = batch(4)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, ok := ratelimit.RetryAfter(err)
+	assert.True(t, ok)
+	assert.NoError(t, batch(2))
+}
+
+// 测试默认配置允许一次修改上千个方块
+func TestBlockServiceDefaultBatch(t *testing.T) {
+	ctx := logging.WithClientIP(context.Background(), "10.0.0.1")
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	cfg := config.Default()
+	blockService := services.NewBlockService(s)
+	blockService.SetEditLimits(cfg.Edit.Limits)
+	blockService.SetRateLimiter(ratelimit.New(cfg.RateLimit))
+	batch := batchUpdater(ctx, blockService)
+
+	assert.NoError(t, batch(1000))
+	assert.NoError(t, batch(1000))
+	assert.Equal(t, codes.PermissionDenied, status.Code(batch(cfg.Edit.Limits[store.DefaultRole]+1)))
+}
+
+// batchUpdater 返回一次修改 n 个方块的函数
+func batchUpdater(ctx context.Context, blockService *services.BlockService) func(n int) error {
+	return func(n int) error {
+		var blocks []*blockpb.Block
+		for x := 0; x < n; x++ {
+			blocks = append(blocks, &blockpb.Block{X: int32(x), Y: 20, W: 1})
+		}
+		_, err := blockService.UpdateBlocks(ctx, &blockpb.UpdateBlocksRequest{Blocks: blocks})
+		return err
+	}
+}
+
+type viewStream struct {
+	blockpb.BlockService_StreamViewServer
+	ctx     context.Context
+	updates chan *blockpb.ViewUpdate
+}
+
+func (s *viewStream) Context() context.Context { return s.ctx }
+
+func (s *viewStream) Send(update *blockpb.ViewUpdate) error {
+	s.updates <- update
+	return nil
+}
+
+// 测试视野内的区块按距离加载，移动后卸载离开视野的区块，只推送视野内的修改
+func TestBlockServiceStreamView(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	blockService := services.NewBlockService(s)
+	_, err = s.UpdateBlocks(store.DefaultWorld, map[store.Vec3]int{{X: 1, Y: 1, Z: 1}: 3, {X: 100, Y: 1, Z: 1}: 4}, "v")
+	require.NoError(t, err)
+
+	_, err = blockService.UpdateView(ctx, &blockpb.UpdateViewRequest{Id: "bob", Distance: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stream := &viewStream{ctx: ctx, updates: make(chan *blockpb.ViewUpdate, 64)}
+	go blockService.StreamView(&blockpb.StreamViewRequest{
+		Id: "bob", X: 1, Z: 1, Distance: 1,
+		Cached: []*blockpb.CachedChunk{{P: 0, Q: 0, Version: "v"}},
+	}, stream)
+	next := func(typ blockpb.ViewUpdate_Type) *blockpb.ViewUpdate {
+		select {
+		case update := <-stream.updates:
+			require.Equal(t, typ, update.Type, "%v", update)
+			return update
+		case <-time.After(time.Second):
+			t.Fatalf("no %v update", typ)
+			return nil
+		}
+	}
+
+	// 缓存的版本仍有效，不再发送方块
+	center := next(blockpb.ViewUpdate_LOAD)
+	assert.Equal(t, [2]int32{0, 0}, [2]int32{center.P, center.Q})
+	assert.True(t, center.Cached)
+	assert.Empty(t, center.Blocks)
+	for range 4 {
+		update := next(blockpb.ViewUpdate_LOAD)
+		assert.Equal(t, int32(1), update.P*update.P+update.Q*update.Q)
+	}
+
+	_, err = blockService.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{X: 200, Y: 1, Z: 1, W: 5, P: 6})
+	require.NoError(t, err)
+	resp, err := blockService.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{X: 2, Y: 1, Z: 1, W: 5})
+	require.NoError(t, err)
+	update := next(blockpb.ViewUpdate_UPDATE)
+	assert.Equal(t, resp.Version, update.Version)
+	assert.Equal(t, []int32{2, 1, 1, 5}, update.Changes)
+
+	// 移动到区块 (3, 0)，原来的五个区块离开视野
+	_, err = blockService.UpdateView(ctx, &blockpb.UpdateViewRequest{Id: "bob", X: 100, Z: 1, Distance: 1})
+	require.NoError(t, err)
+	for range 5 {
+		next(blockpb.ViewUpdate_UNLOAD)
+	}
+	center = next(blockpb.ViewUpdate_LOAD)
+	assert.Equal(t, [2]int32{3, 0}, [2]int32{center.P, center.Q})
+	require.Len(t, center.Blocks, 1)
+	assert.Equal(t, int32(4), center.Blocks[0].W)
+	for range 4 {
+		next(blockpb.ViewUpdate_LOAD)
+	}
+
+	_, err = blockService.UpdateView(ctx, &blockpb.UpdateViewRequest{Id: "bob", Distance: services.MaxViewDistance + 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	select {
+	case extra := <-stream.updates:
+		t.Fatalf("unexpected update %v", extra)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/perlinson/gocraft-server/internal/logging"
+	chatpb "github.com/perlinson/gocraft-server/proto/chat"
+	"google.golang.org/grpc/status"
+)
+
+// LegacyChat 把聊天提供给旧版 yamux/JSON-RPC 客户端，注册为 "Chat" 服务。
+// 旧版协议没有登录，Send 需要带上 HTTP 登录得到的令牌。
+// 消息通过调用客户端的 "Chat.Receive" 推送
+type LegacyChat struct {
+	chat    *ChatService
+	resolve logging.UserResolver
+
+	mu      sync.Mutex
+	cancels map[string]func()
+}
+
+// LegacyClient 是旧版会话回调客户端的连接，*server.Session 实现了它
+type LegacyClient interface {
+	Call(serviceMethod string, args interface{}, reply interface{}) error
+}
+
+// LegacyChatArgs 是 Chat.Send 的参数。Channel 为 global、local、whisper 或 team，
+// 空表示 global
+type LegacyChatArgs struct {
+	Token   string
+	Channel string
+	To      string
+	Player  string
+	Text    string
+}
+
+// LegacyChatReply 是发出的消息，执行命令时 ID 为 0，Text 是命令的输出
+type LegacyChatReply struct {
+	ID   int64
+	Text string
+}
+
+// LegacyChatMessage 是推送给客户端 Chat.Receive 的消息
+type LegacyChatMessage struct {
+	ID      int64
+	Channel string
+	From    string
+	To      string
+	Team    string
+	Text    string
+	Time    int64
+}
+
+func NewLegacyChat(chat *ChatService, resolve logging.UserResolver) *LegacyChat {
+	return &LegacyChat{
+		chat:    chat,
+		resolve: resolve,
+		cancels: make(map[string]func()),
+	}
+}
+
+// Send 实现 Chat.Send
+func (l *LegacyChat) Send(args *LegacyChatArgs, reply *LegacyChatReply) error {
+	ctx := context.Background()
+	if userID, ok := l.resolve(args.Token); ok {
+		ctx = logging.WithUserID(ctx, userID)
+	}
+	channel, ok := chatpb.Channel_value[strings.ToUpper(args.Channel)]
+	if args.Channel != "" && !ok {
+		return errors.New("unknown channel " + args.Channel)
+	}
+	resp, err := l.chat.SendMessage(ctx, &chatpb.SendMessageRequest{
+		Channel: chatpb.Channel(channel),
+		Text:    args.Text,
+		To:      args.To,
+		Player:  args.Player,
+	})
+	if err != nil {
+		return errors.New(status.Convert(err).Message())
+	}
+	if resp.Message == nil {
+		// 命令的输出
+		reply.Text = resp.Output
+		return nil
+	}
+	reply.ID, reply.Text = resp.Message.Id, resp.Message.Text
+	return nil
+}
+
+// Connect 把全局消息和玩家 player 附近的消息推送给 client，直到 Disconnect。
+// 旧版客户端没有登录，收不到私聊和队伍消息
+func (l *LegacyChat) Connect(player string, client LegacyClient) {
+	ch, cancel := l.chat.Subscribe("", player)
+	done := make(chan struct{})
+	l.mu.Lock()
+	if old, ok := l.cancels[player]; ok {
+		old()
+	}
+	l.cancels[player] = func() {
+		cancel()
+		close(done)
+	}
+	l.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case msg := <-ch:
+				var ok bool
+				err := client.Call("Chat.Receive", &LegacyChatMessage{
+					ID:      msg.Id,
+					Channel: strings.ToLower(msg.Channel.String()),
+					From:    msg.From,
+					To:      msg.To,
+					Team:    msg.Team,
+					Text:    msg.Text,
+					Time:    msg.Time,
+				}, &ok)
+				if err != nil {
+					slog.Debug("pushing legacy chat message failed", "player", player, "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Disconnect 停止向玩家 player 推送消息
+func (l *LegacyChat) Disconnect(player string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cancel, ok := l.cancels[player]; ok {
+		cancel()
+		delete(l.cancels, player)
+	}
+}
@@ -0,0 +1,549 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/chat"
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	chatpb "github.com/perlinson/gocraft-server/proto/chat"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTeamName 是队伍名的最大长度
+const maxTeamName = 32
+
+// ChatService 实现聊天。发言需要登录，消息经过过滤、限流后写入聊天记录，
+// 再推送给能收到该频道的订阅者
+type ChatService struct {
+	chatpb.UnimplementedChatServiceServer
+	store    *Store.Store
+	players  *PlayerService
+	limiter  *ratelimit.Limiter
+	filter   *chat.Filter
+	commands *commands.Registry
+
+	maxLength   int
+	localRadius float64
+
+	mu    sync.RWMutex
+	subs  map[*chatSub]struct{}
+	teams map[string]string // 用户名 -> 队伍
+}
+
+// chatSub 是一个消息订阅者，user 为空的订阅者只收到全局和附近的消息
+type chatSub struct {
+	user   string
+	player string
+	ch     chan *chatpb.ChatMessage
+}
+
+func NewChatService(store *Store.Store, players *PlayerService) *ChatService {
+	return &ChatService{
+		store:       store,
+		players:     players,
+		maxLength:   256,
+		localRadius: 32,
+		subs:        make(map[*chatSub]struct{}),
+		teams:       make(map[string]string),
+	}
+}
+
+// SetFilter 设置屏蔽词过滤器
+func (s *ChatService) SetFilter(f *chat.Filter) {
+	s.filter = f
+}
+
+// SetLimits 设置消息的最大字符数和附近聊天的范围（方块）
+func (s *ChatService) SetLimits(maxLength int, localRadius float64) {
+	s.maxLength = maxLength
+	s.localRadius = localRadius
+}
+
+// SetCommands 设置命令，以 "/" 开头的消息作为命令执行
+func (s *ChatService) SetCommands(r *commands.Registry) {
+	s.commands = r
+}
+
+// SetRateLimiter 设置发言限流器，gRPC、HTTP 和旧版协议共用
+func (s *ChatService) SetRateLimiter(l *ratelimit.Limiter) {
+	s.limiter = l
+}
+
+// caller 返回已登录的调用者
+func (s *ChatService) caller(ctx context.Context) (*Store.User, error) {
+	userID := logging.UserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "chat requires login")
+	}
+	id, err := strconv.ParseInt(userID, 10, 32)
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "user %s cannot chat", userID)
+	}
+	user, err := s.store.GetUserByID(ctx, int32(id))
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up user failed", "error", err)
+		return nil, status.Error(codes.Internal, "looking up user failed")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "user %s not found", userID)
+	}
+	return user, nil
+}
+
+// SendMessage 发送一条消息
+func (s *ChatService) SendMessage(ctx context.Context, req *chatpb.SendMessageRequest) (*chatpb.SendMessageResponse, error) {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty message")
+	}
+	if n := utf8.RuneCountInString(text); n > s.maxLength {
+		return nil, status.Errorf(codes.InvalidArgument, "message has %d characters, at most %d are allowed", n, s.maxLength)
+	}
+	if err := s.limiter.Check(ratelimit.Chat, ratelimit.ClientKeys(ctx, "")...); err != nil {
+		return nil, err
+	}
+	// 命令不发送也不记录，被禁言的用户也可以执行
+	if s.commands != nil && strings.HasPrefix(text, "/") {
+		sender := commands.Sender{Name: user.Username, Role: user.Role, Player: req.Player}
+		output, err := s.commands.Execute(ctx, sender, text)
+		if err != nil {
+			return nil, err
+		}
+		return &chatpb.SendMessageResponse{Output: output}, nil
+	}
+	mute, err := s.store.GetChatMute(ctx, user.Username)
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up mute failed", "error", err)
+		return nil, status.Error(codes.Internal, "looking up mute failed")
+	}
+	if now := time.Now(); mute.Active(now) {
+		if mute.Until == nil {
+			return nil, status.Errorf(codes.PermissionDenied, "you are muted: %s", mute.Reason)
+		}
+		return nil, status.Errorf(codes.PermissionDenied, "you are muted for %v: %s", mute.Until.Sub(now).Round(time.Second), mute.Reason)
+	}
+
+	msg := &chatpb.ChatMessage{Channel: req.Channel, From: user.Username}
+	switch req.Channel {
+	case chatpb.Channel_GLOBAL:
+	case chatpb.Channel_LOCAL:
+		world, _, ok := s.players.position(req.Player)
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "player %q is not online", req.Player)
+		}
+		msg.Player, msg.World = req.Player, world
+	case chatpb.Channel_WHISPER:
+		if !s.online(req.To) {
+			return nil, status.Errorf(codes.NotFound, "user %q is not online", req.To)
+		}
+		msg.To = req.To
+	case chatpb.Channel_TEAM:
+		s.mu.RLock()
+		msg.Team = s.teams[user.Username]
+		s.mu.RUnlock()
+		if msg.Team == "" {
+			return nil, status.Error(codes.FailedPrecondition, "you are not in a team")
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown channel %v", req.Channel)
+	}
+
+	cleaned, filtered := s.filter.Clean(text)
+	entry := &Store.ChatMessage{
+		Time:      time.Now(),
+		Channel:   strings.ToLower(req.Channel.String()),
+		World:     msg.World,
+		Sender:    msg.From,
+		Recipient: msg.To,
+		Team:      msg.Team,
+		Text:      cleaned,
+	}
+	if filtered {
+		entry.Original = text
+	}
+	if err := s.store.LogChatMessage(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "logging chat message failed", "error", err)
+		return nil, status.Error(codes.Internal, "logging chat message failed")
+	}
+	msg.Id, msg.Text, msg.Time = entry.ID, cleaned, entry.Time.UnixMilli()
+
+	slog.DebugContext(ctx, "chat message", "channel", entry.Channel, "from", msg.From, "filtered", filtered)
+	s.deliver(msg)
+	return &chatpb.SendMessageResponse{Message: msg}, nil
+}
+
+// online 判断用户是否有消息订阅
+func (s *ChatService) online(user string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for sub := range s.subs {
+		if sub.user == user && user != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver 把消息推送给能收到它的订阅者，发送者的订阅总能收到自己的消息。
+// 跟不上的订阅者会丢失消息
+func (s *ChatService) deliver(msg *chatpb.ChatMessage) {
+	var sender *playerState
+	if msg.Channel == chatpb.Channel_LOCAL {
+		sender = s.playerState(msg.Player)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for sub := range s.subs {
+		if !s.receives(sub, msg, sender) {
+			continue
+		}
+		select {
+		case sub.ch <- msg:
+		default:
+		}
+	}
+}
+
+// playerState 是玩家的世界和位置
+type playerState struct {
+	world   string
+	x, y, z float32
+}
+
+func (s *ChatService) playerState(id string) *playerState {
+	if id == "" {
+		return nil
+	}
+	world, state, ok := s.players.position(id)
+	if !ok {
+		return nil
+	}
+	return &playerState{world, state.X, state.Y, state.Z}
+}
+
+// receives 判断 sub 能否收到 msg，必须持有 s.mu
+func (s *ChatService) receives(sub *chatSub, msg *chatpb.ChatMessage, sender *playerState) bool {
+	if sub.user != "" && sub.user == msg.From {
+		return true
+	}
+	switch msg.Channel {
+	case chatpb.Channel_GLOBAL:
+		return true
+	case chatpb.Channel_LOCAL:
+		p := s.playerState(sub.player)
+		if sender == nil || p == nil || p.world != sender.world {
+			return false
+		}
+		dx, dy, dz := float64(p.x-sender.x), float64(p.y-sender.y), float64(p.z-sender.z)
+		return dx*dx+dy*dy+dz*dz <= s.localRadius*s.localRadius
+	case chatpb.Channel_WHISPER:
+		return sub.user == msg.To
+	case chatpb.Channel_TEAM:
+		return sub.user != "" && s.teams[sub.user] == msg.Team
+	}
+	return false
+}
+
+// Subscribe 订阅用户 user 能收到的消息，player 是其玩家 ID，用于附近聊天。
+// user 为空时只收到全局和附近的消息。返回的函数取消订阅
+func (s *ChatService) Subscribe(user, player string) (<-chan *chatpb.ChatMessage, func()) {
+	sub := &chatSub{user: user, player: player, ch: make(chan *chatpb.ChatMessage, 64)}
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+	return sub.ch, func() {
+		s.mu.Lock()
+		delete(s.subs, sub)
+		s.mu.Unlock()
+	}
+}
+
+// StreamMessages 推送调用者能收到的消息
+func (s *ChatService) StreamMessages(req *chatpb.StreamMessagesRequest, stream chatpb.ChatService_StreamMessagesServer) error {
+	user, err := s.caller(stream.Context())
+	if err != nil {
+		return err
+	}
+	ch, cancel := s.Subscribe(user.Username, req.Player)
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case msg := <-ch:
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// JoinTeam 加入队伍，空队伍名表示离开。队伍只保存在内存中
+func (s *ChatService) JoinTeam(ctx context.Context, req *chatpb.JoinTeamRequest) (*chatpb.JoinTeamResponse, error) {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	team := strings.TrimSpace(req.Team)
+	if utf8.RuneCountInString(team) > maxTeamName {
+		return nil, status.Errorf(codes.InvalidArgument, "team name is longer than %d characters", maxTeamName)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if team == "" {
+		delete(s.teams, user.Username)
+		return &chatpb.JoinTeamResponse{}, nil
+	}
+	s.teams[user.Username] = team
+	resp := &chatpb.JoinTeamResponse{}
+	for member, t := range s.teams {
+		if t == team {
+			resp.Members = append(resp.Members, member)
+		}
+	}
+	sort.Strings(resp.Members)
+	return resp, nil
+}
+
+// requireAdmin 要求调用者是管理员，返回其用户名
+func (s *ChatService) requireAdmin(ctx context.Context) (string, error) {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return "", err
+	}
+	if user.Role != Store.AdminRole {
+		return "", status.Error(codes.PermissionDenied, "only admins can mute")
+	}
+	return user.Username, nil
+}
+
+// Mute 禁言用户，只有管理员可以调用
+func (s *ChatService) Mute(ctx context.Context, req *chatpb.MuteRequest) (*chatpb.MuteResponse, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Seconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "mute duration must not be negative")
+	}
+	mute, err := s.mute(ctx, req.Username, time.Duration(req.Seconds)*time.Second, req.Reason, admin)
+	if err != nil {
+		return nil, err
+	}
+	resp := &chatpb.MuteResponse{}
+	if mute.Until != nil {
+		resp.Until = mute.Until.UnixMilli()
+	}
+	return resp, nil
+}
+
+// Unmute 解除禁言，只有管理员可以调用
+func (s *ChatService) Unmute(ctx context.Context, req *chatpb.UnmuteRequest) (*chatpb.UnmuteResponse, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	muted, err := s.unmute(ctx, req.Username, admin)
+	if err != nil {
+		return nil, err
+	}
+	return &chatpb.UnmuteResponse{Muted: muted}, nil
+}
+
+// mute 禁言 username d 时长，d 为 0 时永久禁言
+func (s *ChatService) mute(ctx context.Context, username string, d time.Duration, reason, by string) (*Store.ChatMute, error) {
+	user, err := s.store.GetUser(ctx, username)
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up user failed", "error", err)
+		return nil, status.Error(codes.Internal, "looking up user failed")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user %q not found", username)
+	}
+	mute := &Store.ChatMute{Username: username, Reason: reason, By: by}
+	if d > 0 {
+		until := time.Now().Add(d)
+		mute.Until = &until
+	}
+	if err := s.store.SetChatMute(ctx, mute); err != nil {
+		slog.ErrorContext(ctx, "storing mute failed", "error", err)
+		return nil, status.Error(codes.Internal, "storing mute failed")
+	}
+	slog.InfoContext(ctx, "user muted", "username", username, "duration", d, "reason", reason, "by", by)
+	return mute, nil
+}
+
+func (s *ChatService) unmute(ctx context.Context, username, by string) (bool, error) {
+	muted, err := s.store.DeleteChatMute(ctx, username)
+	if err != nil {
+		slog.ErrorContext(ctx, "deleting mute failed", "error", err)
+		return false, status.Error(codes.Internal, "deleting mute failed")
+	}
+	if muted {
+		slog.InfoContext(ctx, "user unmuted", "username", username, "by", by)
+	}
+	return muted, nil
+}
+
+// PruneLog 每隔 interval 删除早于 retention 的聊天记录，直到 ctx 结束
+func (s *ChatService) PruneLog(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := s.store.PruneChatLog(ctx, now.Add(-retention))
+			if err != nil {
+				slog.ErrorContext(ctx, "pruning chat log failed", "error", err)
+			} else if n > 0 {
+				slog.InfoContext(ctx, "chat log pruned", "messages", n)
+			}
+		}
+	}
+}
+
+// RegisterRoutes 注册 HTTP 路由，请求体是对应 RPC 请求的 JSON
+func (s *ChatService) RegisterRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	g := r.Group("/api/chat", auth)
+	g.POST("/send", editRoute(s.SendMessage))
+	g.POST("/team", editRoute(s.JoinTeam))
+}
+
+// RegisterAdminRoutes 注册管理路由，auth 限定为管理员。
+// GET /admin/chat/log?user=&before=&limit= 按时间倒序查看聊天记录，
+// POST /admin/chat/mutes 禁言，DELETE /admin/chat/mutes/:username 解除禁言
+func (s *ChatService) RegisterAdminRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	r.GET("/admin/chat/log", auth, s.httpLog)
+	r.GET("/admin/chat/mutes", auth, s.httpMutes)
+	r.POST("/admin/chat/mutes", auth, s.httpMute)
+	r.DELETE("/admin/chat/mutes/:username", auth, s.httpUnmute)
+}
+
+func (s *ChatService) httpLog(c *gin.Context) {
+	before, berr := strconv.ParseInt(c.DefaultQuery("before", "0"), 10, 64)
+	limit, lerr := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if berr != nil || lerr != nil || limit < 1 || limit > 1000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "before must be a message id and limit 1 to 1000"})
+		return
+	}
+	messages, err := s.store.ChatLog(c.Request.Context(), c.Query("user"), before, limit)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "reading chat log failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "reading chat log failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+func (s *ChatService) httpMutes(c *gin.Context) {
+	mutes, err := s.store.ChatMutes(c.Request.Context())
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "reading mutes failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "reading mutes failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"mutes": mutes})
+}
+
+// httpMute 禁言，duration 如 "10m"，为空时永久禁言
+func (s *ChatService) httpMute(c *gin.Context) {
+	var req struct {
+		Username string `json:"username"`
+		Duration string `json:"duration"`
+		Reason   string `json:"reason"`
+	}
+	if err := c.BindJSON(&req); err != nil || req.Username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	var d time.Duration
+	if req.Duration != "" {
+		var err error
+		if d, err = time.ParseDuration(req.Duration); err != nil || d < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid duration"})
+			return
+		}
+	}
+	mute, err := s.mute(c.Request.Context(), req.Username, d, req.Reason, "admin")
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mute)
+}
+
+func (s *ChatService) httpUnmute(c *gin.Context) {
+	muted, err := s.unmute(c.Request.Context(), c.Param("username"), "admin")
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	if !muted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user is not muted"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"username": c.Param("username")})
+}
+
+// RegisterCommands 注册 /mute 和 /unmute
+func (s *ChatService) RegisterCommands(r *commands.Registry) error {
+	err := r.Register(&commands.Command{
+		Name: "mute",
+		Help: "mutes a user, for good without a duration",
+		Args: []commands.Arg{
+			{Name: "user", Type: commands.String},
+			{Name: "duration", Type: commands.Duration, Optional: true},
+			{Name: "reason", Type: commands.Text, Optional: true},
+		},
+		Run: func(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+			mute, err := s.mute(ctx, args.String("user"), args.Duration("duration"), args.String("reason"), sender.Name)
+			if err != nil {
+				return "", err
+			}
+			if mute.Until == nil {
+				return "muted " + mute.Username, nil
+			}
+			return "muted " + mute.Username + " until " + mute.Until.Format(time.RFC3339), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return r.Register(&commands.Command{
+		Name: "unmute",
+		Help: "lets a muted user chat again",
+		Args: []commands.Arg{{Name: "user", Type: commands.String}},
+		Run: func(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+			muted, err := s.unmute(ctx, args.String("user"), sender.Name)
+			if err != nil {
+				return "", err
+			}
+			if !muted {
+				return args.String("user") + " was not muted", nil
+			}
+			return "unmuted " + args.String("user"), nil
+		},
+	})
+}
@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/chat"
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	chatpb "github.com/perlinson/gocraft-server/proto/chat"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 测试聊天频道、过滤、禁言与聊天记录
+func TestChatService(t *testing.T) {
+	ctx := context.Background()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	playerService := services.NewPlayerService(nil)
+	chatService := services.NewChatService(s, playerService)
+	chatService.SetFilter(chat.NewFilter([]string{"darn"}))
+	chatService.SetLimits(16, 10)
+
+	_, err = chatService.SendMessage(ctx, &chatpb.SendMessageRequest{Text: "hi"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	login := func(name string) context.Context {
+		user, err := s.CreateUser(ctx, name, "secret", "")
+		require.NoError(t, err)
+		return logging.WithUserID(ctx, strconv.Itoa(int(user.ID)))
+	}
+	alice, bob, carol := login("alice"), login("bob"), login("carol")
+	aliceMsgs, cancel := chatService.Subscribe("alice", "p-alice")
+	defer cancel()
+	bobMsgs, cancel := chatService.Subscribe("bob", "p-bob")
+	defer cancel()
+	carolMsgs, cancel := chatService.Subscribe("carol", "p-carol")
+	defer cancel()
+	// recv 返回 ch 收到的下一条消息，没有消息时返回 nil
+	recv := func(ch <-chan *chatpb.ChatMessage) *chatpb.ChatMessage {
+		select {
+		case msg := <-ch:
+			return msg
+		case <-time.After(20 * time.Millisecond):
+			return nil
+		}
+	}
+
+	_, err = chatService.SendMessage(alice, &chatpb.SendMessageRequest{Text: "this message is too long"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// 全局消息所有人都能收到，屏蔽词被替换
+	resp, err := chatService.SendMessage(alice, &chatpb.SendMessageRequest{Text: "darn it"})
+	require.NoError(t, err)
+	assert.Equal(t, "**** it", resp.Message.Text)
+	for _, ch := range []<-chan *chatpb.ChatMessage{aliceMsgs, bobMsgs, carolMsgs} {
+		msg := recv(ch)
+		require.NotNil(t, msg)
+		assert.Equal(t, "alice", msg.From)
+	}
+
+	// 私聊只有收发双方能收到
+	_, err = chatService.SendMessage(alice, &chatpb.SendMessageRequest{Channel: chatpb.Channel_WHISPER, To: "dave", Text: "hi"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = chatService.SendMessage(alice, &chatpb.SendMessageRequest{Channel: chatpb.Channel_WHISPER, To: "bob", Text: "psst"})
+	require.NoError(t, err)
+	assert.NotNil(t, recv(aliceMsgs))
+	assert.Equal(t, "psst", recv(bobMsgs).GetText())
+	assert.Nil(t, recv(carolMsgs))
+
+	// 队伍消息只有队员能收到
+	_, err = chatService.SendMessage(bob, &chatpb.SendMessageRequest{Channel: chatpb.Channel_TEAM, Text: "go"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = chatService.JoinTeam(bob, &chatpb.JoinTeamRequest{Team: "red"})
+	require.NoError(t, err)
+	team, err := chatService.JoinTeam(carol, &chatpb.JoinTeamRequest{Team: "red"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob", "carol"}, team.Members)
+	_, err = chatService.SendMessage(bob, &chatpb.SendMessageRequest{Channel: chatpb.Channel_TEAM, Text: "go"})
+	require.NoError(t, err)
+	assert.Nil(t, recv(aliceMsgs))
+	assert.NotNil(t, recv(bobMsgs))
+	assert.Equal(t, "red", recv(carolMsgs).GetTeam())
+
+	// 附近消息只有范围内的玩家能收到
+	for id, x := range map[string]float32{"p-alice": 0, "p-bob": 5, "p-carol": 50} {
+		_, err = playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: id, State: &playerpb.PlayerState{X: x, Y: 16}})
+		require.NoError(t, err)
+	}
+	_, err = chatService.SendMessage(alice, &chatpb.SendMessageRequest{Channel: chatpb.Channel_LOCAL, Player: "p-nobody", Text: "hey"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = chatService.SendMessage(alice, &chatpb.SendMessageRequest{Channel: chatpb.Channel_LOCAL, Player: "p-alice", Text: "hey"})
+	require.NoError(t, err)
+	assert.NotNil(t, recv(aliceMsgs))
+	assert.NotNil(t, recv(bobMsgs))
+	assert.Nil(t, recv(carolMsgs))
+
+	// 只有管理员可以禁言，被禁言后不能发言
+	_, err = chatService.Mute(bob, &chatpb.MuteRequest{Username: "alice", Seconds: 60})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.SetUserRole(ctx, "carol", store.AdminRole)
+	require.NoError(t, err)
+	mute, err := chatService.Mute(carol, &chatpb.MuteRequest{Username: "alice", Seconds: 60, Reason: "spam"})
+	require.NoError(t, err)
+	assert.Greater(t, mute.Until, time.Now().UnixMilli())
+	_, err = chatService.SendMessage(alice, &chatpb.SendMessageRequest{Text: "hi"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	unmute, err := chatService.Unmute(carol, &chatpb.UnmuteRequest{Username: "alice"})
+	require.NoError(t, err)
+	assert.True(t, unmute.Muted)
+	_, err = chatService.SendMessage(alice, &chatpb.SendMessageRequest{Text: "hi"})
+	assert.NoError(t, err)
+	assert.NotNil(t, recv(bobMsgs))
+
+	// 以 "/" 开头的消息作为命令执行，不发送也不记录
+	chatService.SetCommands(commands.NewRegistry(map[string][]string{"player": {"command.help"}}))
+	resp, err = chatService.SendMessage(alice, &chatpb.SendMessageRequest{Text: "/help help"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Message)
+	assert.Contains(t, resp.Output, "/help [command]")
+	assert.Nil(t, recv(bobMsgs))
+
+	// 聊天记录保存原文
+	log, err := s.ChatLog(ctx, "alice", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, log, 4)
+	first := log[len(log)-1]
+	assert.Equal(t, "**** it", first.Text)
+	assert.Equal(t, "darn it", first.Original)
+	assert.Equal(t, "bob", log[2].Recipient)
+}
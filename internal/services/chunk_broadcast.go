@@ -0,0 +1,65 @@
+package services
+
+import (
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+)
+
+// chunkID 是区块所在的世界和 (p, q)
+type chunkID struct {
+	world string
+	p, q  int32
+}
+
+// subscribe 把区块 id 的更新发送到 ch，StreamView 用一个 ch 订阅视野内的所有区块
+func (s *BlockService) subscribe(id chunkID, ch chan *blockpb.ChunkUpdate) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	if s.subs[id] == nil {
+		s.subs[id] = make(map[chan *blockpb.ChunkUpdate]struct{})
+	}
+	s.subs[id][ch] = struct{}{}
+}
+
+func (s *BlockService) unsubscribe(id chunkID, ch chan *blockpb.ChunkUpdate) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	delete(s.subs[id], ch)
+	if len(s.subs[id]) == 0 {
+		delete(s.subs, id)
+	}
+}
+
+// broadcast 把每个更新发送给世界 world 中对应区块的订阅者，
+// 处理不及的订阅者会丢失更新，而不是阻塞写入
+func (s *BlockService) broadcast(world string, updates []*blockpb.ChunkUpdate) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	for _, update := range updates {
+		for ch := range s.subs[chunkID{world, update.P, update.Q}] {
+			select {
+			case ch <- update:
+			default:
+			}
+		}
+	}
+}
+
+// chunkUpdates 把修改的方块按区块分组，每个区块一个更新。
+// 只是与修改相邻的区块得到不含方块的更新
+func chunkUpdates(changes map[Store.Vec3]int, chunks []Store.Vec3, version string) []*blockpb.ChunkUpdate {
+	updates := make(map[[2]int32]*blockpb.ChunkUpdate, len(chunks))
+	list := make([]*blockpb.ChunkUpdate, 0, len(chunks))
+	for _, c := range chunks {
+		update := &blockpb.ChunkUpdate{P: c.X, Q: c.Z, Version: version}
+		updates[[2]int32{c.X, c.Z}] = update
+		list = append(list, update)
+	}
+	for id, w := range changes {
+		cid := id.Chunkid()
+		if update, ok := updates[[2]int32{cid.X, cid.Z}]; ok {
+			update.Blocks = append(update.Blocks, id.X, id.Y, id.Z, int32(w))
+		}
+	}
+	return list
+}
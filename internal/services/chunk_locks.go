@@ -0,0 +1,52 @@
+package services
+
+import (
+	"hash/fnv"
+	"slices"
+	"sync"
+
+	Store "github.com/perlinson/gocraft-server/internal/store"
+)
+
+// chunkLockShards 是区块锁的分片数，不同分片的区块可以并行写入
+const chunkLockShards = 256
+
+// chunkLocks 为每个区块的读写排序。写入在存储和广播期间持有所有修改区块的锁，
+// 订阅者按存储顺序收到区块的更新，查询也不会看到写了一半的批量修改
+type chunkLocks struct {
+	shards [chunkLockShards]sync.RWMutex
+}
+
+func chunkShard(world string, id Store.Vec3) int {
+	h := fnv.New32a()
+	h.Write([]byte(world))
+	h.Write([]byte{byte(id.X), byte(id.X >> 8), byte(id.X >> 16), byte(id.X >> 24),
+		byte(id.Z), byte(id.Z >> 8), byte(id.Z >> 16), byte(id.Z >> 24)})
+	return int(h.Sum32() % chunkLockShards)
+}
+
+// lock 为世界 world 的区块加写锁，返回释放锁的函数。
+// 分片按升序加锁，修改重叠区块的写入不会死锁
+func (l *chunkLocks) lock(world string, chunks []Store.Vec3) (unlock func()) {
+	shards := make([]int, 0, len(chunks))
+	for _, c := range chunks {
+		shards = append(shards, chunkShard(world, c))
+	}
+	slices.Sort(shards)
+	shards = slices.Compact(shards)
+	for _, i := range shards {
+		l.shards[i].Lock()
+	}
+	return func() {
+		for i := len(shards) - 1; i >= 0; i-- {
+			l.shards[shards[i]].Unlock()
+		}
+	}
+}
+
+// rlock 为世界 world 的区块 id 加读锁
+func (l *chunkLocks) rlock(world string, id Store.Vec3) (unlock func()) {
+	m := &l.shards[chunkShard(world, id)]
+	m.RLock()
+	return m.RUnlock
+}
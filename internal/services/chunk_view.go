@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/perlinson/gocraft-server/internal/metrics"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxViewDistance 是 StreamView 视距的上限，单位为区块
+const MaxViewDistance = 32
+
+// chunkView 是一个 StreamView 流的视野。UpdateView 设置中心和视距并通知 moved，
+// 其余由流处理
+type chunkView struct {
+	mu       sync.Mutex
+	p, q     int32
+	distance int32
+
+	moved   chan struct{}
+	updates chan *blockpb.ChunkUpdate
+	cancel  context.CancelFunc
+}
+
+// move 把视野中心设为方块位置 (x, z) 所在的区块
+func (v *chunkView) move(x, z float32, distance int32) {
+	c := Store.Vec3{X: int32(math.Floor(float64(x))), Z: int32(math.Floor(float64(z)))}.Chunkid()
+	v.mu.Lock()
+	v.p, v.q, v.distance = c.X, c.Z, distance
+	v.mu.Unlock()
+	select {
+	case v.moved <- struct{}{}:
+	default:
+	}
+}
+
+// chunks 返回视野内的区块，近的在前
+func (v *chunkView) chunks() [][2]int32 {
+	v.mu.Lock()
+	p, q, d := v.p, v.q, v.distance
+	v.mu.Unlock()
+
+	var chunks [][2]int32
+	for dp := -d; dp <= d; dp++ {
+		for dq := -d; dq <= d; dq++ {
+			if dp*dp+dq*dq <= d*d {
+				chunks = append(chunks, [2]int32{p + dp, q + dq})
+			}
+		}
+	}
+	dist := func(c [2]int32) int32 { return (c[0]-p)*(c[0]-p) + (c[1]-q)*(c[1]-q) }
+	slices.SortFunc(chunks, func(a, b [2]int32) int {
+		if da, db := dist(a), dist(b); da != db {
+			return int(da - db)
+		}
+		if a[0] != b[0] {
+			return int(a[0] - b[0])
+		}
+		return int(a[1] - b[1])
+	})
+	return chunks
+}
+
+func checkViewDistance(distance int32) error {
+	if distance < 0 || distance > MaxViewDistance {
+		return status.Errorf(codes.InvalidArgument, "view distance must be between 0 and %d, got %d", MaxViewDistance, distance)
+	}
+	return nil
+}
+
+// StreamView 实现 StreamView RPC。进入视野的区块先订阅再读取，不会漏掉修改；
+// 读取之前存储的修改可能在 LOAD 之后再次到达，重复应用没有影响。
+// 与 StreamChunk 相同，处理不及的流会丢失修改
+func (s *BlockService) StreamView(req *blockpb.StreamViewRequest, stream blockpb.BlockService_StreamViewServer) error {
+	if req.Id == "" {
+		return status.Error(codes.InvalidArgument, "view id is required")
+	}
+	if err := checkViewDistance(req.Distance); err != nil {
+		return err
+	}
+	world, err := s.World(stream.Context(), req.World, false)
+	if err != nil {
+		return err
+	}
+	metrics.ChunkViews.Inc()
+	defer metrics.ChunkViews.Dec()
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	v := &chunkView{
+		moved:   make(chan struct{}, 1),
+		updates: make(chan *blockpb.ChunkUpdate, 1024),
+		cancel:  cancel,
+	}
+	v.move(req.X, req.Z, req.Distance)
+	s.viewsMu.Lock()
+	if old := s.views[req.Id]; old != nil {
+		old.cancel()
+	}
+	s.views[req.Id] = v
+	s.viewsMu.Unlock()
+
+	// loaded 是已经发送的视野内区块
+	loaded := make(map[[2]int32]bool)
+	cached := make(map[[2]int32]string, len(req.Cached))
+	for _, c := range req.Cached {
+		cached[[2]int32{c.P, c.Q}] = c.Version
+	}
+	defer func() {
+		s.viewsMu.Lock()
+		if s.views[req.Id] == v {
+			delete(s.views, req.Id)
+		}
+		s.viewsMu.Unlock()
+		for c := range loaded {
+			s.unsubscribe(chunkID{world.Name, c[0], c[1]}, v.updates)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if stream.Context().Err() == nil {
+				return status.Error(codes.Aborted, "view replaced by a newer stream")
+			}
+			return ctx.Err()
+		case <-v.moved:
+			if err := s.syncView(ctx, stream, world.Name, v, loaded, cached); err != nil {
+				return err
+			}
+		case update := <-v.updates:
+			if err := sendViewUpdate(stream, loaded, update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// syncView 卸载离开视野的区块，由近到远加载新的区块，期间视野移动时重新开始
+func (s *BlockService) syncView(ctx context.Context, stream blockpb.BlockService_StreamViewServer, world string, v *chunkView, loaded map[[2]int32]bool, cached map[[2]int32]string) error {
+restart:
+	for {
+		chunks := v.chunks()
+		inView := make(map[[2]int32]bool, len(chunks))
+		for _, c := range chunks {
+			inView[c] = true
+		}
+		for c := range loaded {
+			if inView[c] {
+				continue
+			}
+			s.unsubscribe(chunkID{world, c[0], c[1]}, v.updates)
+			delete(loaded, c)
+			if err := stream.Send(&blockpb.ViewUpdate{Type: blockpb.ViewUpdate_UNLOAD, P: c[0], Q: c[1]}); err != nil {
+				return err
+			}
+		}
+
+		for _, c := range chunks {
+			if loaded[c] {
+				continue
+			}
+			select {
+			case <-v.moved:
+				continue restart
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			s.subscribe(chunkID{world, c[0], c[1]}, v.updates)
+			version, blocks, err := s.readChunk(world, Store.Vec3{X: c[0], Z: c[1]})
+			if err != nil {
+				slog.ErrorContext(ctx, "reading chunk failed", "world", world, "p", c[0], "q", c[1], "error", err)
+				return status.Error(codes.Internal, "reading chunk failed")
+			}
+			update := &blockpb.ViewUpdate{Type: blockpb.ViewUpdate_LOAD, P: c[0], Q: c[1], Version: version}
+			// 请求中的缓存版本只对第一次加载有效
+			if cachedVersion, ok := cached[c]; ok && cachedVersion == version {
+				update.Cached = true
+			} else {
+				update.Blocks = blocks
+			}
+			delete(cached, c)
+			loaded[c] = true
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// sendViewUpdate 转发仍在视野内的区块的修改
+func sendViewUpdate(stream blockpb.BlockService_StreamViewServer, loaded map[[2]int32]bool, update *blockpb.ChunkUpdate) error {
+	if !loaded[[2]int32{update.P, update.Q}] {
+		return nil
+	}
+	return stream.Send(&blockpb.ViewUpdate{
+		Type:    blockpb.ViewUpdate_UPDATE,
+		P:       update.P,
+		Q:       update.Q,
+		Version: update.Version,
+		Changes: update.Blocks,
+	})
+}
+
+// UpdateView 实现 UpdateView RPC
+func (s *BlockService) UpdateView(ctx context.Context, req *blockpb.UpdateViewRequest) (*blockpb.UpdateViewResponse, error) {
+	if err := checkViewDistance(req.Distance); err != nil {
+		return nil, err
+	}
+	s.viewsMu.Lock()
+	v := s.views[req.Id]
+	s.viewsMu.Unlock()
+	if v == nil {
+		return nil, status.Errorf(codes.NotFound, "no view %q, open it with StreamView", req.Id)
+	}
+	v.move(req.X, req.Z, req.Distance)
+	return &blockpb.UpdateViewResponse{}, nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	commandpb "github.com/perlinson/gocraft-server/proto/command"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CommandService 通过 gRPC 执行斜杠命令，调用者需要登录，
+// 能执行哪些命令由其角色的权限节点决定
+type CommandService struct {
+	commandpb.UnimplementedCommandServiceServer
+	store    *Store.Store
+	commands *commands.Registry
+}
+
+func NewCommandService(store *Store.Store, registry *commands.Registry) *CommandService {
+	return &CommandService{store: store, commands: registry}
+}
+
+// sender 返回已登录的调用者，player 是其玩家 ID
+func (s *CommandService) sender(ctx context.Context, player string) (commands.Sender, error) {
+	userID := logging.UserID(ctx)
+	if userID == "" {
+		return commands.Sender{}, status.Error(codes.Unauthenticated, "commands require login")
+	}
+	id, err := strconv.ParseInt(userID, 10, 32)
+	if err != nil {
+		return commands.Sender{}, status.Errorf(codes.PermissionDenied, "user %s cannot run commands", userID)
+	}
+	user, err := s.store.GetUserByID(ctx, int32(id))
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up user failed", "error", err)
+		return commands.Sender{}, status.Error(codes.Internal, "looking up user failed")
+	}
+	if user == nil {
+		return commands.Sender{}, status.Errorf(codes.PermissionDenied, "user %s not found", userID)
+	}
+	return commands.Sender{Name: user.Username, Role: user.Role, Player: player}, nil
+}
+
+// Execute 执行一条命令
+func (s *CommandService) Execute(ctx context.Context, req *commandpb.ExecuteRequest) (*commandpb.ExecuteResponse, error) {
+	sender, err := s.sender(ctx, req.Player)
+	if err != nil {
+		return nil, err
+	}
+	output, err := s.commands.Execute(ctx, sender, req.Line)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "command executed", "user", sender.Name, "line", req.Line)
+	return &commandpb.ExecuteResponse{Output: output}, nil
+}
+
+// Complete 补全命令行的最后一个词
+func (s *CommandService) Complete(ctx context.Context, req *commandpb.CompleteRequest) (*commandpb.CompleteResponse, error) {
+	sender, err := s.sender(ctx, req.Player)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, hint := s.commands.Complete(ctx, sender, req.Line)
+	return &commandpb.CompleteResponse{Suggestions: suggestions, Hint: hint}, nil
+}
+
+// ListCommands 列出调用者能执行的命令
+func (s *CommandService) ListCommands(ctx context.Context, req *commandpb.ListCommandsRequest) (*commandpb.ListCommandsResponse, error) {
+	sender, err := s.sender(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	resp := &commandpb.ListCommandsResponse{}
+	for _, c := range s.commands.Commands(sender) {
+		resp.Commands = append(resp.Commands, &commandpb.Command{
+			Name:       c.Name,
+			Aliases:    c.Aliases,
+			Usage:      c.Usage(),
+			Help:       c.Help,
+			Permission: c.Node(),
+		})
+	}
+	return resp, nil
+}
+
+// RegisterRoutes 注册 HTTP 路由，请求体是对应 RPC 请求的 JSON
+func (s *CommandService) RegisterRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	g := r.Group("/api/commands", auth)
+	g.POST("/execute", editRoute(s.Execute))
+	g.POST("/complete", editRoute(s.Complete))
+	g.GET("", editRoute(s.ListCommands))
+}
+
+// RegisterAdminRoutes 注册管理路由，auth 限定为管理员。
+// POST /admin/commands {"line": "/time noon"} 以控制台身份执行命令
+func (s *CommandService) RegisterAdminRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	r.POST("/admin/commands", auth, s.httpExecute)
+}
+
+func (s *CommandService) httpExecute(c *gin.Context) {
+	var req struct {
+		Line string `json:"line"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	output, err := s.commands.Execute(c.Request.Context(), commands.ConsoleSender, req.Line)
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "admin command executed", "line", req.Line)
+	c.JSON(http.StatusOK, gin.H{"output": output})
+}
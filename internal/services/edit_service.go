@@ -0,0 +1,700 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/blocks"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/schematic"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	editpb "github.com/perlinson/gocraft-server/proto/edit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// EditService 实现区域编辑。每个用户有一个编辑会话，保存选区、剪贴板和撤销历史，
+// 所有修改都通过 BlockService.ApplyBlocks 批量写入并广播
+type EditService struct {
+	editpb.UnimplementedEditServiceServer
+	store  *Store.Store
+	blocks *BlockService
+
+	// 每次操作最多修改的方块数，按用户角色
+	limits    map[string]int
+	undoDepth int
+
+	mu       sync.Mutex
+	sessions map[string]*editSession
+}
+
+// editSession 是一个用户的编辑状态，mu 保证同一用户的操作依次执行
+type editSession struct {
+	mu sync.Mutex
+	// role 是最近一次操作时用户的角色
+	role string
+	// world 是选区所在的世界，操作都作用于它
+	world     string
+	selected  bool
+	min, max  Store.Vec3
+	clipboard *clipboard
+	undo      []*edit
+	redo      []*edit
+}
+
+// clipboard 保存复制的方块，坐标相对于选区的最小角
+type clipboard struct {
+	size   Store.Vec3
+	blocks map[Store.Vec3]int
+}
+
+// edit 是一次操作前后世界 world 中的方块，before 中 Store.Unset 表示原来没有存储的方块
+type edit struct {
+	world  string
+	before map[Store.Vec3]int
+	after  map[Store.Vec3]int
+}
+
+func NewEditService(store *Store.Store, blockService *BlockService, limits map[string]int, undoDepth int) *EditService {
+	return &EditService{
+		store:     store,
+		blocks:    blockService,
+		limits:    limits,
+		undoDepth: undoDepth,
+		sessions:  make(map[string]*editSession),
+	}
+}
+
+// Select 设置选区，两个角都包含在内。选区所在的世界同时成为编辑的世界，
+// 剪贴板可以粘贴到其他世界
+func (s *EditService) Select(ctx context.Context, req *editpb.SelectRequest) (*editpb.SelectResponse, error) {
+	p1, p2 := vec3(req.Pos1), vec3(req.Pos2)
+	if err := checkCoords(p1, p2); err != nil {
+		return nil, err
+	}
+	world, err := s.blocks.World(ctx, req.World, false)
+	if err != nil {
+		return nil, err
+	}
+	sess, _, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	sess.world = world.Name
+	sess.min = Store.Vec3{X: min(p1.X, p2.X), Y: min(p1.Y, p2.Y), Z: min(p1.Z, p2.Z)}
+	sess.max = Store.Vec3{X: max(p1.X, p2.X), Y: max(p1.Y, p2.Y), Z: max(p1.Z, p2.Z)}
+	sess.selected = true
+	return &editpb.SelectResponse{
+		Min:    pbVec3(sess.min),
+		Max:    pbVec3(sess.max),
+		Volume: volume(sess.min, sess.max),
+	}, nil
+}
+
+// Fill 用 w 填满选区
+func (s *EditService) Fill(ctx context.Context, req *editpb.FillRequest) (*editpb.EditResponse, error) {
+	if err := s.blocks.CheckBlockType(req.W); err != nil {
+		return nil, err
+	}
+	sess, limit, err := s.selection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	if err := checkLimit(volume(sess.min, sess.max), limit); err != nil {
+		return nil, err
+	}
+	changes := make(map[Store.Vec3]int)
+	forEach(sess.min, sess.max, func(p Store.Vec3) {
+		changes[p] = int(req.W)
+	})
+	return s.apply(ctx, sess, changes)
+}
+
+// Replace 把选区中类型为 from 的方块替换为 to，只涉及存储的方块
+func (s *EditService) Replace(ctx context.Context, req *editpb.ReplaceRequest) (*editpb.EditResponse, error) {
+	if err := s.blocks.CheckBlockType(req.To); err != nil {
+		return nil, err
+	}
+	sess, limit, err := s.selection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	changes := make(map[Store.Vec3]int)
+	err = s.blocks.chunks.RangeRegion(sess.world, sess.min, sess.max, func(p Store.Vec3, w int) {
+		if w == int(req.From) {
+			changes[p] = int(req.To)
+		}
+	})
+	if err != nil {
+		return nil, s.storeError(ctx, err)
+	}
+	if err := checkLimit(int64(len(changes)), limit); err != nil {
+		return nil, err
+	}
+	return s.apply(ctx, sess, changes)
+}
+
+// Hollow 用 w 填充选区内部，保留一格厚的外壳
+func (s *EditService) Hollow(ctx context.Context, req *editpb.HollowRequest) (*editpb.EditResponse, error) {
+	if err := s.blocks.CheckBlockType(req.W); err != nil {
+		return nil, err
+	}
+	sess, limit, err := s.selection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	inner := Store.Vec3{X: sess.min.X + 1, Y: sess.min.Y + 1, Z: sess.min.Z + 1}
+	outer := Store.Vec3{X: sess.max.X - 1, Y: sess.max.Y - 1, Z: sess.max.Z - 1}
+	if inner.X > outer.X || inner.Y > outer.Y || inner.Z > outer.Z {
+		return nil, status.Error(codes.FailedPrecondition, "selection has no inside")
+	}
+	if err := checkLimit(volume(inner, outer), limit); err != nil {
+		return nil, err
+	}
+	changes := make(map[Store.Vec3]int)
+	forEach(inner, outer, func(p Store.Vec3) {
+		changes[p] = int(req.W)
+	})
+	return s.apply(ctx, sess, changes)
+}
+
+// Walls 用 w 填充选区的四个侧面
+func (s *EditService) Walls(ctx context.Context, req *editpb.WallsRequest) (*editpb.EditResponse, error) {
+	if err := s.blocks.CheckBlockType(req.W); err != nil {
+		return nil, err
+	}
+	sess, limit, err := s.selection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	// 侧面的方块数：底面去掉内部后乘以高度
+	lo, hi := sess.min, sess.max
+	ring := volume(Store.Vec3{X: lo.X, Z: lo.Z}, Store.Vec3{X: hi.X, Z: hi.Z}) -
+		volume(Store.Vec3{X: lo.X + 1, Z: lo.Z + 1}, Store.Vec3{X: hi.X - 1, Z: hi.Z - 1})
+	n := int64(math.MaxInt64)
+	if height := int64(hi.Y) - int64(lo.Y) + 1; ring <= math.MaxInt64/height {
+		n = ring * height
+	}
+	if err := checkLimit(n, limit); err != nil {
+		return nil, err
+	}
+	changes := make(map[Store.Vec3]int)
+	forEach(lo, hi, func(p Store.Vec3) {
+		if p.X == lo.X || p.X == hi.X || p.Z == lo.Z || p.Z == hi.Z {
+			changes[p] = int(req.W)
+		}
+	})
+	return s.apply(ctx, sess, changes)
+}
+
+// Copy 把选区中存储的方块复制到剪贴板
+func (s *EditService) Copy(ctx context.Context, req *editpb.CopyRequest) (*editpb.CopyResponse, error) {
+	sess, limit, err := s.selection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	cb := &clipboard{
+		size:   Store.Vec3{X: sess.max.X - sess.min.X + 1, Y: sess.max.Y - sess.min.Y + 1, Z: sess.max.Z - sess.min.Z + 1},
+		blocks: make(map[Store.Vec3]int),
+	}
+	err = s.blocks.chunks.RangeRegion(sess.world, sess.min, sess.max, func(p Store.Vec3, w int) {
+		cb.blocks[Store.Vec3{X: p.X - sess.min.X, Y: p.Y - sess.min.Y, Z: p.Z - sess.min.Z}] = w
+	})
+	if err != nil {
+		return nil, s.storeError(ctx, err)
+	}
+	if err := checkLimit(int64(len(cb.blocks)), limit); err != nil {
+		return nil, err
+	}
+	sess.clipboard = cb
+	return &editpb.CopyResponse{Blocks: int32(len(cb.blocks)), Size: pbVec3(cb.size)}, nil
+}
+
+// Paste 把剪贴板粘贴到 at，先镜像再绕 y 轴旋转
+func (s *EditService) Paste(ctx context.Context, req *editpb.PasteRequest) (*editpb.EditResponse, error) {
+	rotation, err := schematic.NormalizeRotation(req.Rotation)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sess, limit, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	cb := sess.clipboard
+	if cb == nil {
+		return nil, status.Error(codes.FailedPrecondition, "clipboard is empty, copy a selection first")
+	}
+	if err := checkLimit(int64(len(cb.blocks)), limit); err != nil {
+		return nil, err
+	}
+	at := vec3(req.At)
+	if err := checkCoords(at); err != nil {
+		return nil, err
+	}
+	changes := make(map[Store.Vec3]int, len(cb.blocks))
+	for p, w := range cb.blocks {
+		t := schematic.Transform(p, cb.size, rotation, req.MirrorX, req.MirrorZ)
+		changes[Store.Vec3{X: at.X + t.X, Y: at.Y + t.Y, Z: at.Z + t.Z}] = w
+	}
+	return s.apply(ctx, sess, changes)
+}
+
+// Move 把选区中存储的方块移动 offset，原位置留下空气，选区随之移动
+func (s *EditService) Move(ctx context.Context, req *editpb.MoveRequest) (*editpb.EditResponse, error) {
+	sess, limit, err := s.selection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	offset := vec3(req.Offset)
+	if err := checkCoords(offset); err != nil {
+		return nil, err
+	}
+	lo := Store.Vec3{X: sess.min.X + offset.X, Y: sess.min.Y + offset.Y, Z: sess.min.Z + offset.Z}
+	hi := Store.Vec3{X: sess.max.X + offset.X, Y: sess.max.Y + offset.Y, Z: sess.max.Z + offset.Z}
+	if err := checkCoords(lo, hi); err != nil {
+		return nil, err
+	}
+	moved := make(map[Store.Vec3]int)
+	err = s.blocks.chunks.RangeRegion(sess.world, sess.min, sess.max, func(p Store.Vec3, w int) {
+		moved[Store.Vec3{X: p.X + offset.X, Y: p.Y + offset.Y, Z: p.Z + offset.Z}] = w
+	})
+	if err != nil {
+		return nil, s.storeError(ctx, err)
+	}
+	changes := make(map[Store.Vec3]int, 2*len(moved))
+	for p := range moved {
+		changes[Store.Vec3{X: p.X - offset.X, Y: p.Y - offset.Y, Z: p.Z - offset.Z}] = int(blocks.Air)
+	}
+	for p, w := range moved {
+		changes[p] = w
+	}
+	if err := checkLimit(int64(len(changes)), limit); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.apply(ctx, sess, changes)
+	if err != nil {
+		return nil, err
+	}
+	sess.min, sess.max = lo, hi
+	return resp, nil
+}
+
+// Undo 撤销最近一次操作
+func (s *EditService) Undo(ctx context.Context, req *editpb.UndoRequest) (*editpb.EditResponse, error) {
+	sess, _, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	if len(sess.undo) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "nothing to undo")
+	}
+	e := sess.undo[len(sess.undo)-1]
+	resp, err := s.write(ctx, e.world, e.before)
+	if err != nil {
+		return nil, err
+	}
+	sess.undo = sess.undo[:len(sess.undo)-1]
+	sess.redo = append(sess.redo, e)
+	return resp, nil
+}
+
+// Redo 重做最近一次撤销的操作
+func (s *EditService) Redo(ctx context.Context, req *editpb.RedoRequest) (*editpb.EditResponse, error) {
+	sess, _, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+
+	if len(sess.redo) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "nothing to redo")
+	}
+	e := sess.redo[len(sess.redo)-1]
+	resp, err := s.write(ctx, e.world, e.after)
+	if err != nil {
+		return nil, err
+	}
+	sess.redo = sess.redo[:len(sess.redo)-1]
+	sess.undo = append(sess.undo, e)
+	return resp, nil
+}
+
+// ExportSchematic 把选区导出为 schematic 文件，仅限管理员
+func (s *EditService) ExportSchematic(ctx context.Context, req *editpb.ExportSchematicRequest) (*editpb.ExportSchematicResponse, error) {
+	sess, _, err := s.selection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+	if sess.role != Store.AdminRole {
+		return nil, status.Error(codes.PermissionDenied, "exporting schematics requires the admin role")
+	}
+
+	data, n, err := s.exportSchematic(ctx, sess.world, sess.min, sess.max)
+	if err != nil {
+		return nil, err
+	}
+	return &editpb.ExportSchematicResponse{Schematic: data, Blocks: int32(n)}, nil
+}
+
+// ImportSchematic 粘贴 schematic 文件，可以撤销，仅限管理员
+func (s *EditService) ImportSchematic(ctx context.Context, req *editpb.ImportSchematicRequest) (*editpb.EditResponse, error) {
+	sess, limit, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.mu.Unlock()
+	if sess.role != Store.AdminRole {
+		return nil, status.Error(codes.PermissionDenied, "importing schematics requires the admin role")
+	}
+
+	at := vec3(req.At)
+	if err := checkCoords(at); err != nil {
+		return nil, err
+	}
+	changes, err := s.placeSchematic(req.Schematic, at, req.Rotation)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLimit(int64(len(changes)), limit); err != nil {
+		return nil, err
+	}
+	return s.apply(ctx, sess, changes)
+}
+
+func (s *EditService) exportSchematic(ctx context.Context, world string, lo, hi Store.Vec3) ([]byte, int, error) {
+	sc, err := schematic.Export(s.blocks.chunks, world, lo, hi)
+	if err != nil {
+		return nil, 0, s.storeError(ctx, err)
+	}
+	var buf bytes.Buffer
+	if err := sc.Write(&buf, s.blocks.blocks); err != nil {
+		return nil, 0, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return buf.Bytes(), len(sc.Blocks), nil
+}
+
+func (s *EditService) placeSchematic(data []byte, at Store.Vec3, rotation int32) (map[Store.Vec3]int, error) {
+	sc, err := schematic.Read(bytes.NewReader(data), s.blocks.blocks)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	changes, err := sc.Place(at, rotation)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return changes, nil
+}
+
+// session 返回调用者的编辑会话（已加锁）和其角色允许修改的方块数
+func (s *EditService) session(ctx context.Context) (*editSession, int, error) {
+	userID := logging.UserID(ctx)
+	if userID == "" {
+		return nil, 0, status.Error(codes.Unauthenticated, "region edits require login")
+	}
+	id, err := strconv.ParseInt(userID, 10, 32)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.PermissionDenied, "user %s cannot edit", userID)
+	}
+	user, err := s.store.GetUserByID(ctx, int32(id))
+	if err != nil {
+		return nil, 0, s.storeError(ctx, err)
+	}
+	if user == nil {
+		return nil, 0, status.Errorf(codes.PermissionDenied, "user %s not found", userID)
+	}
+	limit, ok := s.limits[user.Role]
+	if !ok {
+		return nil, 0, status.Errorf(codes.PermissionDenied, "role %q cannot use region edits", user.Role)
+	}
+
+	s.mu.Lock()
+	sess, ok := s.sessions[userID]
+	if !ok {
+		sess = &editSession{world: Store.DefaultWorld}
+		s.sessions[userID] = sess
+	}
+	s.mu.Unlock()
+
+	sess.mu.Lock()
+	sess.role = user.Role
+	return sess, limit, nil
+}
+
+// selection 同 session，但要求已经选择了区域
+func (s *EditService) selection(ctx context.Context) (*editSession, int, error) {
+	sess, limit, err := s.session(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !sess.selected {
+		sess.mu.Unlock()
+		return nil, 0, status.Error(codes.FailedPrecondition, "select a region first")
+	}
+	return sess, limit, nil
+}
+
+// apply 记录 changes 覆盖前的方块后写入，并压入撤销栈
+func (s *EditService) apply(ctx context.Context, sess *editSession, changes map[Store.Vec3]int) (*editpb.EditResponse, error) {
+	if len(changes) == 0 {
+		return &editpb.EditResponse{}, nil
+	}
+
+	// 读取受影响范围内存储的方块，没有存储的记为 Unset，撤销时恢复生成的地形
+	first := true
+	var lo, hi Store.Vec3
+	for p := range changes {
+		if first {
+			lo, hi, first = p, p, false
+			continue
+		}
+		lo = Store.Vec3{X: min(lo.X, p.X), Y: min(lo.Y, p.Y), Z: min(lo.Z, p.Z)}
+		hi = Store.Vec3{X: max(hi.X, p.X), Y: max(hi.Y, p.Y), Z: max(hi.Z, p.Z)}
+	}
+	before := make(map[Store.Vec3]int, len(changes))
+	for p := range changes {
+		before[p] = Store.Unset
+	}
+	err := s.blocks.chunks.RangeRegion(sess.world, lo, hi, func(p Store.Vec3, w int) {
+		if _, ok := before[p]; ok {
+			before[p] = w
+		}
+	})
+	if err != nil {
+		return nil, s.storeError(ctx, err)
+	}
+
+	resp, err := s.write(ctx, sess.world, changes)
+	if err != nil {
+		return nil, err
+	}
+	sess.redo = nil
+	if s.undoDepth > 0 {
+		sess.undo = append(sess.undo, &edit{world: sess.world, before: before, after: changes})
+		if len(sess.undo) > s.undoDepth {
+			sess.undo = sess.undo[len(sess.undo)-s.undoDepth:]
+		}
+	}
+	slog.InfoContext(ctx, "region edit", "world", sess.world, "blocks", len(changes), "version", resp.Version)
+	return resp, nil
+}
+
+// write 写入 changes，要求调用者可以修改世界 world
+func (s *EditService) write(ctx context.Context, world string, changes map[Store.Vec3]int) (*editpb.EditResponse, error) {
+	if _, err := s.blocks.World(ctx, world, true); err != nil {
+		return nil, err
+	}
+	version, _, err := s.blocks.ApplyBlocks(ctx, world, changes)
+	if err != nil {
+		return nil, err
+	}
+	return &editpb.EditResponse{Changed: int32(len(changes)), Version: version}, nil
+}
+
+func (s *EditService) storeError(ctx context.Context, err error) error {
+	slog.ErrorContext(ctx, "reading region failed", "error", err)
+	return status.Error(codes.Internal, "reading region failed")
+}
+
+// checkLimit 拒绝超过角色上限的操作
+func checkLimit(n int64, limit int) error {
+	if n > int64(limit) {
+		return status.Errorf(codes.PermissionDenied, "operation changes %d blocks, your role allows %d", n, limit)
+	}
+	return nil
+}
+
+// maxCoord 是编辑坐标的绝对值上限，远超玩家能到达的范围。
+// 坐标和偏移都在范围内时，相加、相减都不会溢出 int32
+const maxCoord = 1 << 29
+
+// checkCoords 拒绝超出编辑范围的坐标
+func checkCoords(vs ...Store.Vec3) error {
+	for _, v := range vs {
+		for _, c := range []int32{v.X, v.Y, v.Z} {
+			if c < -maxCoord || c > maxCoord {
+				return status.Errorf(codes.InvalidArgument, "coordinate %d is outside the world (%d..%d)", c, -maxCoord, maxCoord)
+			}
+		}
+	}
+	return nil
+}
+
+// forEach 遍历从 lo 到 hi 的长方体，包含两端。计数用 int64，hi 为 MaxInt32 时也不会回绕
+func forEach(lo, hi Store.Vec3, f func(p Store.Vec3)) {
+	for x := int64(lo.X); x <= int64(hi.X); x++ {
+		for y := int64(lo.Y); y <= int64(hi.Y); y++ {
+			for z := int64(lo.Z); z <= int64(hi.Z); z++ {
+				f(Store.Vec3{X: int32(x), Y: int32(y), Z: int32(z)})
+			}
+		}
+	}
+}
+
+// volume 返回从 lo 到 hi 的长方体的方块数，超出 int64 时返回 math.MaxInt64
+func volume(lo, hi Store.Vec3) int64 {
+	n := int64(1)
+	for _, d := range []int64{
+		int64(hi.X) - int64(lo.X) + 1,
+		int64(hi.Y) - int64(lo.Y) + 1,
+		int64(hi.Z) - int64(lo.Z) + 1,
+	} {
+		if d <= 0 {
+			return 0
+		}
+		if n > math.MaxInt64/d {
+			return math.MaxInt64
+		}
+		n *= d
+	}
+	return n
+}
+
+func vec3(v *editpb.Vec3) Store.Vec3 {
+	return Store.Vec3{X: v.GetX(), Y: v.GetY(), Z: v.GetZ()}
+}
+
+func pbVec3(v Store.Vec3) *editpb.Vec3 {
+	return &editpb.Vec3{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// RegisterRoutes 注册 HTTP 路由，请求体是对应 RPC 请求的 JSON
+func (s *EditService) RegisterRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	g := r.Group("/api/edit", auth)
+	g.POST("/select", editRoute(s.Select))
+	g.POST("/fill", editRoute(s.Fill))
+	g.POST("/replace", editRoute(s.Replace))
+	g.POST("/hollow", editRoute(s.Hollow))
+	g.POST("/walls", editRoute(s.Walls))
+	g.POST("/copy", editRoute(s.Copy))
+	g.POST("/paste", editRoute(s.Paste))
+	g.POST("/move", editRoute(s.Move))
+	g.POST("/undo", editRoute(s.Undo))
+	g.POST("/redo", editRoute(s.Redo))
+}
+
+// editRoute 把一个编辑 RPC 包装为 HTTP 处理函数，空请求体表示空请求
+func editRoute[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Resp proto.Message](rpc func(context.Context, PReq) (Resp, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := PReq(new(Req))
+		if err := bindProto(c, req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		resp, err := rpc(c.Request.Context(), req)
+		if err != nil {
+			httpError(c, err)
+			return
+		}
+		renderProto(c, http.StatusOK, resp)
+	}
+}
+
+// RegisterAdminRoutes 注册 schematic 导入导出的管理路由，auth 限定为管理员。
+// 导出 GET /admin/schematics/export?from=x,y,z&to=x,y,z，
+// 导入 POST /admin/schematics/import?at=x,y,z&rotation=90，请求体为文件。
+// ?world= 选择世界，默认为默认世界
+func (s *EditService) RegisterAdminRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	r.GET("/admin/schematics/export", auth, s.httpExportSchematic)
+	r.POST("/admin/schematics/import", auth, s.httpImportSchematic)
+}
+
+func (s *EditService) httpExportSchematic(c *gin.Context) {
+	from, ferr := Store.ParseVec3(c.Query("from"))
+	to, terr := Store.ParseVec3(c.Query("to"))
+	if ferr != nil || terr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be x,y,z"})
+		return
+	}
+	lo := Store.Vec3{X: min(from.X, to.X), Y: min(from.Y, to.Y), Z: min(from.Z, to.Z)}
+	hi := Store.Vec3{X: max(from.X, to.X), Y: max(from.Y, to.Y), Z: max(from.Z, to.Z)}
+	world, err := s.adminWorld(c.Query("world"))
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+
+	data, _, err := s.exportSchematic(c.Request.Context(), world, lo, hi)
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="region.schematic"`)
+	c.Data(http.StatusOK, "application/gzip", data)
+}
+
+func (s *EditService) httpImportSchematic(c *gin.Context) {
+	at, err := Store.ParseVec3(c.Query("at"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at must be x,y,z"})
+		return
+	}
+	rotation, err := strconv.ParseInt(c.DefaultQuery("rotation", "0"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rotation"})
+		return
+	}
+	data, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	world, err := s.adminWorld(c.Query("world"))
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+
+	changes, err := s.placeSchematic(data, at, int32(rotation))
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	version, _, err := s.blocks.ApplyBlocks(c.Request.Context(), world, changes)
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "schematic imported", "world", world, "at", at, "blocks", len(changes), "version", version)
+	c.JSON(http.StatusOK, &editpb.EditResponse{Changed: int32(len(changes)), Version: version})
+}
+
+// adminWorld 返回世界 name 的名字，管理接口不检查世界的权限
+func (s *EditService) adminWorld(name string) (string, error) {
+	w := s.blocks.worlds.Lookup(name)
+	if w == nil {
+		return "", status.Errorf(codes.NotFound, "world %q does not exist", name)
+	}
+	return w.Name, nil
+}
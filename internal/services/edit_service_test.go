@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	editpb "github.com/perlinson/gocraft-server/proto/edit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 测试区域编辑、剪贴板、撤销重做与角色上限
+func TestEditService(t *testing.T) {
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	editService := services.NewEditService(s, services.NewBlockService(s), map[string]int{"player": 10, "builder": 1000}, 5)
+
+	_, err = editService.Select(context.Background(), &editpb.SelectRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	user, err := s.CreateUser(context.Background(), "bob", "secret", "")
+	require.NoError(t, err)
+	ctx := logging.WithUserID(context.Background(), strconv.Itoa(int(user.ID)))
+	block := func(x, y, z int32) int {
+		w, err := s.GetBlock(store.DefaultWorld, store.Vec3{X: x, Y: y, Z: z})
+		require.NoError(t, err)
+		return w
+	}
+	stored := func() int {
+		n := 0
+		require.NoError(t, s.RangeRegion(store.DefaultWorld, store.Vec3{X: -100, Y: -100, Z: -100}, store.Vec3{X: 100, Y: 100, Z: 100}, func(store.Vec3, int) { n++ }))
+		return n
+	}
+
+	_, err = editService.Fill(ctx, &editpb.FillRequest{W: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	sel, err := editService.Select(ctx, &editpb.SelectRequest{Pos1: &editpb.Vec3{X: 2, Y: 2, Z: 2}, Pos2: &editpb.Vec3{}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(27), sel.Volume)
+
+	_, err = editService.Fill(ctx, &editpb.FillRequest{W: 1})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "27 blocks exceed the player limit")
+	found, err := s.SetUserRole(ctx, "bob", "builder")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	resp, err := editService.Fill(ctx, &editpb.FillRequest{W: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(27), resp.Changed)
+	assert.Equal(t, 27, stored())
+
+	resp, err = editService.Hollow(ctx, &editpb.HollowRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Changed)
+	assert.Equal(t, 0, block(1, 1, 1))
+
+	resp, err = editService.Replace(ctx, &editpb.ReplaceRequest{From: 1, To: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int32(26), resp.Changed)
+	assert.Equal(t, 3, block(0, 2, 0))
+
+	// 撤销回到 fill 之前，未存储的方块被删除，恢复生成的地形
+	for range 3 {
+		_, err = editService.Undo(ctx, &editpb.UndoRequest{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, stored())
+	_, err = editService.Undo(ctx, &editpb.UndoRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = editService.Redo(ctx, &editpb.RedoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 27, stored())
+	assert.Equal(t, 1, block(1, 1, 1))
+
+	// 复制两个方块并旋转 90 度粘贴
+	_, err = editService.Undo(ctx, &editpb.UndoRequest{})
+	require.NoError(t, err)
+	_, err = s.UpdateBlocks(store.DefaultWorld, map[store.Vec3]int{{X: 0, Y: 0, Z: 0}: 5, {X: 1, Y: 0, Z: 0}: 6}, "v")
+	require.NoError(t, err)
+	_, err = editService.Select(ctx, &editpb.SelectRequest{Pos1: &editpb.Vec3{}, Pos2: &editpb.Vec3{X: 1, Z: 2}})
+	require.NoError(t, err)
+	cp, err := editService.Copy(ctx, &editpb.CopyRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), cp.Blocks)
+	_, err = editService.Paste(ctx, &editpb.PasteRequest{At: &editpb.Vec3{X: 10}, Rotation: 90})
+	require.NoError(t, err)
+	assert.Equal(t, 5, block(12, 0, 0))
+	assert.Equal(t, 6, block(12, 0, 1))
+	_, err = editService.Paste(ctx, &editpb.PasteRequest{Rotation: 45})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// 移动留下空气，选区随之移动
+	_, err = editService.Move(ctx, &editpb.MoveRequest{Offset: &editpb.Vec3{Y: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, 0, block(0, 0, 0))
+	assert.Equal(t, 5, block(0, 1, 0))
+	cp, err = editService.Copy(ctx, &editpb.CopyRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), cp.Blocks)
+}
+
+// 测试 int32 边界上的选区不会溢出或无限循环
+func TestEditServiceCoordinateBounds(t *testing.T) {
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	editService := services.NewEditService(s, services.NewBlockService(s), map[string]int{"player": 10}, 5)
+	user, err := s.CreateUser(context.Background(), "bob", "secret", "")
+	require.NoError(t, err)
+	ctx := logging.WithUserID(context.Background(), strconv.Itoa(int(user.ID)))
+	pos := func(x, y, z int32) *editpb.Vec3 { return &editpb.Vec3{X: x, Y: y, Z: z} }
+
+	for _, c := range [][2]*editpb.Vec3{
+		{pos(math.MaxInt32, 0, 0), pos(math.MaxInt32, 0, 0)},
+		{pos(math.MinInt32, 0, 0), pos(math.MinInt32, 0, 0)},
+		{pos(math.MinInt32, math.MinInt32, math.MinInt32), pos(math.MaxInt32, math.MaxInt32, math.MaxInt32)},
+	} {
+		_, err = editService.Select(ctx, &editpb.SelectRequest{Pos1: c[0], Pos2: c[1]})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "%v %v", c[0], c[1])
+	}
+
+	// 范围内最大的选区超过上限，而不是溢出成很小的体积
+	sel, err := editService.Select(ctx, &editpb.SelectRequest{Pos1: pos(-1<<29, -1<<29, -1<<29), Pos2: pos(1<<29, 1<<29, 1<<29)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), sel.Volume)
+	_, err = editService.Fill(ctx, &editpb.FillRequest{W: 1})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = editService.Walls(ctx, &editpb.WallsRequest{W: 1})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// 移出范围的选区被拒绝
+	sel, err = editService.Select(ctx, &editpb.SelectRequest{Pos1: pos(1<<29, 0, 0), Pos2: pos(1<<29, 0, 0)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), sel.Volume)
+	resp, err := editService.Fill(ctx, &editpb.FillRequest{W: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Changed)
+	_, err = editService.Move(ctx, &editpb.MoveRequest{Offset: pos(1, 0, 0)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = editService.Move(ctx, &editpb.MoveRequest{Offset: pos(math.MaxInt32, 0, 0)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// 长方体工具在 int32 两端也能正确计算和结束
+	max := store.Vec3{X: math.MaxInt32, Y: math.MaxInt32, Z: math.MaxInt32}
+	min := store.Vec3{X: math.MinInt32, Y: math.MinInt32, Z: math.MinInt32}
+	assert.Equal(t, int64(1), services.Volume(max, max))
+	assert.Equal(t, int64(1), services.Volume(min, min))
+	assert.Equal(t, int64(math.MaxInt64), services.Volume(min, max))
+	assert.Equal(t, int64(1<<32), services.Volume(store.Vec3{X: math.MinInt32}, store.Vec3{X: math.MaxInt32}))
+	var visited []store.Vec3
+	services.ForEach(store.Vec3{X: math.MaxInt32 - 1, Y: math.MaxInt32, Z: math.MaxInt32}, max, func(p store.Vec3) { visited = append(visited, p) })
+	assert.Equal(t, []store.Vec3{{X: math.MaxInt32 - 1, Y: math.MaxInt32, Z: math.MaxInt32}, max}, visited)
+	visited = nil
+	services.ForEach(max, max, func(p store.Vec3) { visited = append(visited, p) })
+	assert.Equal(t, []store.Vec3{max}, visited)
+	visited = nil
+	services.ForEach(min, min, func(p store.Vec3) { visited = append(visited, p) })
+	assert.Equal(t, []store.Vec3{min}, visited)
+}
@@ -0,0 +1,28 @@
+package services
+
+import "time"
+
+// SetClock 替换玩家服务的时钟
+func (s *PlayerService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// Subscribers 返回 StreamEvents 订阅者的数量
+func (s *PlayerService) Subscribers() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.subs)
+}
+
+// ChunkSubscribers 返回世界 world 中区块 (p, q) 的订阅者数量
+func (s *BlockService) ChunkSubscribers(world string, p, q int32) int {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	return len(s.subs[chunkID{world, p, q}])
+}
+
+// 区域编辑的长方体工具
+var (
+	Volume  = volume
+	ForEach = forEach
+)
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthChecker 实现 grpc.health.v1，定期探测存储连接并更新各服务状态。
+// 依赖存储的服务用 Register 注册，数据库不可达时与整个服务器（空服务名）一起标记为 NOT_SERVING
+type HealthChecker struct {
+	*health.Server
+	store *Store.Store
+
+	mu       sync.Mutex
+	services []string
+	status   healthpb.HealthCheckResponse_ServingStatus
+}
+
+func NewHealthChecker(store *Store.Store) *HealthChecker {
+	h := &HealthChecker{
+		Server:   health.NewServer(),
+		store:    store,
+		services: []string{""},
+	}
+	h.check(context.Background())
+	return h
+}
+
+// Register 在 s 上注册依赖存储的 gRPC 服务 impl，其健康状态随存储变化
+func (h *HealthChecker) Register(s grpc.ServiceRegistrar, desc *grpc.ServiceDesc, impl interface{}) {
+	s.RegisterService(desc, impl)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.services = append(h.services, desc.ServiceName)
+	h.SetServingStatus(desc.ServiceName, h.status)
+}
+
+// Run 每隔 interval 探测一次存储，直到 ctx 结束
+func (h *HealthChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			h.Shutdown()
+			return
+		case <-ticker.C:
+			h.check(ctx)
+		}
+	}
+}
+
+func (h *HealthChecker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := h.store.Ping(ctx); err != nil {
+		slog.Warn("store unreachable, marking services not serving", "error", err)
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.status = status
+	for _, service := range h.services {
+		h.SetServingStatus(service, status)
+	}
+}
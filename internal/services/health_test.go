@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// 测试数据库不可达时依赖存储的服务变为 NOT_SERVING
+func TestHealthChecker(t *testing.T) {
+	st, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	checker := services.NewHealthChecker(st)
+	server := grpc.NewServer()
+	checker.Register(server, &blockpb.BlockService_ServiceDesc, services.NewBlockService(st))
+	checker.Register(server, &playerpb.PlayerService_ServiceDesc, services.NewPlayerService(nil))
+
+	statusOf := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := checker.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		assert.NoError(t, err)
+		return resp.Status
+	}
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf("block.BlockService"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf("player.PlayerService"))
+
+	st.Close()
+	go checker.Run(ctx, 5*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return statusOf("block.BlockService") == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf("player.PlayerService"))
+
+	// 未注册的服务没有状态
+	_, err = checker.Check(ctx, &healthpb.HealthCheckRequest{Service: "chat.ChatService"})
+	assert.Error(t, err)
+}
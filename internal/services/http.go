@@ -0,0 +1,57 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var httpStatusCodes = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+// httpError 把 gRPC 错误转换为 HTTP 响应，REST 接口与 gRPC 返回一致的错误
+func httpError(c *gin.Context, err error) {
+	st := status.Convert(err)
+	code, ok := httpStatusCodes[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	if d, ok := ratelimit.RetryAfter(err); ok {
+		c.Header("Retry-After", ratelimit.RetryAfterSeconds(d))
+	}
+	c.JSON(code, gin.H{"error": st.Message()})
+}
+
+// bindProto 用 protojson 解析请求体，与 WebSocket 网关的 JSON 帧使用相同的字段名和编码，
+// 空请求体表示空请求
+func bindProto(c *gin.Context, m proto.Message) error {
+	body, err := c.GetRawData()
+	if err != nil || len(body) == 0 {
+		return err
+	}
+	return protojson.Unmarshal(body, m)
+}
+
+// renderProto 用 protojson 输出 m
+func renderProto(c *gin.Context, code int, m proto.Message) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "encoding response failed"})
+		return
+	}
+	c.Data(code, "application/json; charset=utf-8", data)
+}
@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/ratelimit"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(t *testing.T, limiter *ratelimit.Limiter) (*gin.Engine, *services.AuthService) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+
+	router := gin.New()
+	authService := services.NewAuthService(s)
+	authService.SetRateLimiter(limiter)
+	authService.RegisterRoutes(router)
+	return router, authService
+}
+
+func request(router *gin.Engine, method, path, token, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// 测试 HTTP 接口的令牌校验与在线玩家列表
+func TestHTTPRoutes(t *testing.T) {
+	router, _ := newTestRouter(t, nil)
+	services.NewPlayerService(nil).RegisterRoutes(router)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		return request(router, method, path, token, body)
+	}
+
+	rec := do("POST", "/api/auth/register", "", `{"username":"testuser","password":"password123","email":"test@example.com"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, http.StatusConflict, do("POST", "/api/auth/register", "", `{"username":"testuser","password":"other"}`).Code)
+
+	rec = do("POST", "/api/auth/login", "", `{"username":"testuser","password":"password123"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var login struct {
+		Token string `json:"token"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &login))
+	assert.NotEmpty(t, login.Token)
+
+	assert.Equal(t, http.StatusOK, do("POST", "/api/auth/logout", login.Token, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, do("POST", "/api/auth/logout", login.Token, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, do("POST", "/api/auth/logout", "", "").Code)
+
+	rec = do("GET", "/api/players", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"players":{}}`, rec.Body.String())
+}
+
+// 测试登录限流与连续失败后的账号锁定
+func TestLoginLockout(t *testing.T) {
+	limiter := ratelimit.New(config.RateLimitConfig{Login: config.Rate{PerSecond: 0.01, Burst: 4}})
+	router, authService := newTestRouter(t, limiter)
+	authService.SetLockout(2, time.Minute)
+
+	require.Equal(t, http.StatusOK, request(router, "POST", "/api/auth/register", "", `{"username":"steve","password":"right"}`).Code)
+
+	wrong := `{"username":"steve","password":"wrong"}`
+	assert.Equal(t, http.StatusUnauthorized, request(router, "POST", "/api/auth/login", "", wrong).Code)
+	assert.Equal(t, http.StatusUnauthorized, request(router, "POST", "/api/auth/login", "", wrong).Code)
+
+	// locked, even with the right password
+	rec := request(router, "POST", "/api/auth/login", "", `{"username":"steve","password":"right"}`)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assertRetryAfter(t, rec, 60)
+
+	// the burst of 4 is used up, registering took a token too
+	rec = request(router, "POST", "/api/auth/login", "", wrong)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assertRetryAfter(t, rec, 100)
+	assert.Contains(t, rec.Body.String(), "rate limit")
+	rec = request(router, "POST", "/api/auth/register", "", `{"username":"alex","password":"right"}`)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+// assertRetryAfter 检查响应要求等待不超过 max 秒，密码哈希较慢时（如 -race）等待时间会变短
+func assertRetryAfter(t *testing.T, rec *httptest.ResponseRecorder, max int) {
+	t.Helper()
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.Greater(t, retryAfter, 0)
+	assert.LessOrEqual(t, retryAfter, max)
+}
+
+// 测试方块接口使用 protojson 编码，与 WebSocket 网关的字段名和枚举编码一致
+func TestBlockRoutesProtoJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	router := gin.New()
+	authService := services.NewAuthService(s)
+	authService.RegisterRoutes(router)
+	services.NewBlockService(s).RegisterRoutes(router, authService.RequireAuth())
+
+	require.Equal(t, http.StatusOK, request(router, "POST", "/api/auth/register", "", `{"username":"steve","password":"secret"}`).Code)
+	rec := request(router, "POST", "/api/auth/login", "", `{"username":"steve","password":"secret"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var login struct {
+		Token string `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &login))
+
+	// 枚举按名字解析
+	rec = request(router, "POST", "/api/blocks", login.Token, `{"x":5,"y":10,"z":5,"w":3,"precondition":"BLOCK"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var updated struct {
+		Version string `json:"version"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &updated))
+	assert.NotEmpty(t, updated.Version)
+
+	// 冲突详情使用 lowerCamelCase 字段名
+	rec = request(router, "POST", "/api/blocks", login.Token, `{"x":5,"y":10,"z":5,"w":4,"precondition":"BLOCK","version":"stale"}`)
+	require.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	var conflict struct {
+		Conflict struct {
+			ChunkVersion string `json:"chunkVersion"`
+			Block        struct {
+				W       int32  `json:"w"`
+				Version string `json:"version"`
+			} `json:"block"`
+		} `json:"conflict"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &conflict))
+	assert.Equal(t, updated.Version, conflict.Conflict.ChunkVersion)
+	assert.Equal(t, int32(3), conflict.Conflict.Block.W)
+	assert.Equal(t, updated.Version, conflict.Conflict.Block.Version)
+
+	rec = request(router, "POST", "/api/blocks/batch", login.Token, `{"blocks":[{"x":6,"y":10,"z":5,"w":2}]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var batch struct {
+		Version string `json:"version"`
+		Chunks  []struct {
+			P int32 `json:"p"`
+			Q int32 `json:"q"`
+		} `json:"chunks"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &batch))
+	assert.NotEmpty(t, batch.Version)
+	assert.Len(t, batch.Chunks, 1)
+
+	assert.Equal(t, http.StatusBadRequest, request(router, "POST", "/api/blocks", login.Token, `{"precondition":7.5}`).Code)
+}
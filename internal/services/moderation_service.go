@@ -0,0 +1,646 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LegacySessions 是旧版协议的会话，*server.Server 实现了它
+type LegacySessions interface {
+	// Kick 关闭会话 id，返回会话是否存在
+	Kick(id int32, reason string) bool
+	// KickIP 关闭来自 ip 的所有会话，返回关闭的个数
+	KickIP(ip, reason string) int
+}
+
+// ModerationService 管理封禁、踢出和白名单。用户封禁在登录和注册时检查，
+// IP 封禁还在每次 gRPC 调用、HTTP 请求和旧版协议握手时检查。
+// 白名单模式下只有白名单中的用户和管理员可以登录
+type ModerationService struct {
+	store   *Store.Store
+	auth    *AuthService
+	players *PlayerService
+	legacy  LegacySessions
+
+	mu        sync.Mutex
+	whitelist bool
+	streams   map[*liveStream]struct{}
+	// ipBans 缓存每个 IP 结束最晚的有效封禁，每次调用都要检查，不能每次查数据库
+	ipBans map[string]*Store.Ban
+	// kicked 记录被踢出的用户名在何时之前不能重新登录
+	kicked map[string]time.Time
+}
+
+// kickCooldown 是被踢出的用户重新登录前要等待的时长
+const kickCooldown = time.Minute
+
+// liveStream 是一个进行中的 gRPC 流或 WebSocket 连接，踢出时取消。
+// WebSocket 连接登录后才有用户，所以 userID 是函数
+type liveStream struct {
+	userID func() string
+	ip     string
+	cancel context.CancelCauseFunc
+}
+
+func NewModerationService(store *Store.Store, auth *AuthService, players *PlayerService) *ModerationService {
+	s := &ModerationService{
+		store:   store,
+		auth:    auth,
+		players: players,
+		streams: make(map[*liveStream]struct{}),
+		ipBans:  make(map[string]*Store.Ban),
+		kicked:  make(map[string]time.Time),
+	}
+	if err := s.loadIPBans(context.Background()); err != nil {
+		slog.Error("loading IP bans failed", "error", err)
+	}
+	return s
+}
+
+// loadIPBans 从 store 读取有效的 IP 封禁
+func (s *ModerationService) loadIPBans(ctx context.Context) error {
+	bans, err := s.store.Bans(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range bans {
+		if b := &bans[i]; b.IP != "" && b.Active(now) {
+			s.cacheIPBan(b)
+		}
+	}
+	return nil
+}
+
+// cacheIPBan 记录 b，除非已有结束更晚的封禁，调用时持有 s.mu
+func (s *ModerationService) cacheIPBan(b *Store.Ban) {
+	cur := s.ipBans[b.IP]
+	if cur == nil || cur.Until != nil && (b.Until == nil || b.Until.After(*cur.Until)) {
+		s.ipBans[b.IP] = b
+	}
+}
+
+// ipBan 返回 ip 当前有效的封禁，没有时返回 nil
+func (s *ModerationService) ipBan(ip string, now time.Time) *Store.Ban {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.ipBans[ip]
+	if b != nil && !b.Active(now) {
+		delete(s.ipBans, ip)
+		return nil
+	}
+	return b
+}
+
+// kickedUntil 返回被踢出的用户 username 何时可以重新登录，没有被踢出时返回零值
+func (s *ModerationService) kickedUntil(username string, now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, until := range s.kicked {
+		if !now.Before(until) {
+			delete(s.kicked, name)
+		}
+	}
+	return s.kicked[username]
+}
+
+// SetWhitelist 打开或关闭白名单模式，只影响之后的登录
+func (s *ModerationService) SetWhitelist(enabled bool) {
+	s.mu.Lock()
+	s.whitelist = enabled
+	s.mu.Unlock()
+	slog.Info("whitelist mode changed", "enabled", enabled)
+}
+
+func (s *ModerationService) whitelistEnabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.whitelist
+}
+
+// SetLegacy 设置旧版协议的会话，踢出和封禁 IP 时关闭
+func (s *ModerationService) SetLegacy(l LegacySessions) {
+	s.legacy = l
+}
+
+// banned 返回封禁的错误信息
+func banned(b *Store.Ban, now time.Time) error {
+	msg := "you are banned"
+	if b.Until != nil {
+		msg += fmt.Sprintf(" for %v", b.Until.Sub(now).Round(time.Second))
+	}
+	if b.Reason != "" {
+		msg += ": " + b.Reason
+	}
+	return status.Error(codes.PermissionDenied, msg)
+}
+
+// Admit 是 AuthService 的登录准入检查：拒绝被封禁的用户和 IP，
+// 白名单模式下拒绝不在白名单中的非管理员
+func (s *ModerationService) Admit(ctx context.Context, user *Store.User) error {
+	now := time.Now()
+	ban, err := s.store.ActiveBan(ctx, user.Username, logging.ClientIP(ctx), now)
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up bans failed", "error", err)
+		return status.Error(codes.Internal, "looking up bans failed")
+	}
+	if ban != nil {
+		slog.InfoContext(ctx, "banned login refused", "username", user.Username, "ban", ban.ID)
+		return banned(ban, now)
+	}
+	if until := s.kickedUntil(user.Username, now); !until.IsZero() {
+		return status.Errorf(codes.PermissionDenied, "you were kicked, try again in %v", until.Sub(now).Round(time.Second))
+	}
+	if !s.whitelistEnabled() || user.Role == Store.AdminRole {
+		return nil
+	}
+	ok, err := s.store.Whitelisted(ctx, user.Username)
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up whitelist failed", "error", err)
+		return status.Error(codes.Internal, "looking up whitelist failed")
+	}
+	if !ok {
+		slog.InfoContext(ctx, "login refused by whitelist", "username", user.Username)
+		return status.Error(codes.PermissionDenied, "the server only admits whitelisted users")
+	}
+	return nil
+}
+
+// AdmitLegacy 是旧版协议握手的准入检查。旧版协议没有账号，
+// 白名单模式下拒绝所有连接
+func (s *ModerationService) AdmitLegacy(ip string) error {
+	if s.whitelistEnabled() {
+		return errors.New("whitelist mode admits no legacy clients")
+	}
+	now := time.Now()
+	if ban := s.ipBan(ip, now); ban != nil {
+		return banned(ban, now)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor 拒绝来自被封禁 IP 的调用，包括不需要登录的调用，
+// 要放在设置客户端 IP 的日志拦截器之后
+func (s *ModerationService) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		now := time.Now()
+		if ban := s.ipBan(logging.ClientIP(ctx), now); ban != nil {
+			return nil, banned(ban, now)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Middleware 拒绝来自被封禁 IP 的 HTTP 请求和 WebSocket 连接，
+// 管理接口除外，以免管理员封禁自己的 IP 后无法解封
+func (s *ModerationService) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, "/admin/") {
+			c.Next()
+			return
+		}
+		now := time.Now()
+		if ban := s.ipBan(c.ClientIP(), now); ban != nil {
+			httpError(c, banned(ban, now))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// StreamServerInterceptor 拒绝来自被封禁 IP 的流，并记录进行中的 gRPC 流，
+// 被踢出的流以踢出原因结束
+func (s *ModerationService) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		now := time.Now()
+		if ban := s.ipBan(logging.ClientIP(ss.Context()), now); ban != nil {
+			return banned(ban, now)
+		}
+		userID := logging.UserID(ss.Context())
+		ctx, release := s.Track(ss.Context(), logging.ClientIP(ss.Context()), func() string { return userID })
+		defer release()
+
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		if cause := context.Cause(ctx); status.Code(cause) == codes.Aborted {
+			return cause
+		}
+		return err
+	}
+}
+
+// Track 记录来自 ip、属于 userID() 的用户的连接，如 WebSocket 连接。
+// 踢出或封禁时返回的上下文被取消，context.Cause 是 Aborted 错误，其消息是原因。
+// 连接结束时调用 release
+func (s *ModerationService) Track(ctx context.Context, ip string, userID func() string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stream := &liveStream{userID: userID, ip: ip, cancel: cancel}
+	s.mu.Lock()
+	s.streams[stream] = struct{}{}
+	s.mu.Unlock()
+	return ctx, func() {
+		s.mu.Lock()
+		delete(s.streams, stream)
+		s.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// contextStream 用 ctx 代替流的上下文
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// closeStreams 以 reason 结束 match 的 gRPC 流，返回结束的个数
+func (s *ModerationService) closeStreams(match func(*liveStream) bool, reason string) int {
+	err := status.Error(codes.Aborted, reason)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for stream := range s.streams {
+		if match(stream) {
+			stream.cancel(err)
+			n++
+		}
+	}
+	return n
+}
+
+// kickReason 返回告诉客户端的踢出原因
+func kickReason(reason string) string {
+	if reason == "" {
+		return "kicked"
+	}
+	return "kicked: " + reason
+}
+
+// Kick 踢出 target：用户名对应用户的 gRPC 流、该 ID 的玩家和旧版会话。
+// 用户的令牌失效，kickCooldown 内不能重新登录。
+// 返回踢出的个数，什么都没找到时返回 NotFound
+func (s *ModerationService) Kick(ctx context.Context, target, reason string) (int, error) {
+	n := 0
+	user, err := s.store.GetUser(ctx, target)
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up user failed", "error", err)
+		return 0, status.Error(codes.Internal, "looking up user failed")
+	}
+	if user != nil {
+		userID := strconv.Itoa(int(user.ID))
+		n += s.auth.RevokeUser(userID)
+		n += s.closeStreams(func(l *liveStream) bool { return l.userID() == userID }, kickReason(reason))
+		if n > 0 {
+			s.mu.Lock()
+			s.kicked[user.Username] = time.Now().Add(kickCooldown)
+			s.mu.Unlock()
+		}
+	}
+	if s.players.Kick(ctx, target, reason) {
+		n++
+	}
+	if id, err := strconv.ParseInt(target, 10, 32); err == nil && s.legacy != nil && s.legacy.Kick(int32(id), kickReason(reason)) {
+		n++
+	}
+	if n == 0 {
+		return 0, status.Errorf(codes.NotFound, "%s is neither connected nor an online player", target)
+	}
+	slog.InfoContext(ctx, "kicked", "target", target, "reason", reason, "connections", n)
+	return n, nil
+}
+
+// Ban 封禁用户或 IP d 时长，d 为 0 时永久封禁。被封禁用户的令牌失效，
+// 其连接和来自该 IP 的连接被断开
+func (s *ModerationService) Ban(ctx context.Context, username, ip string, d time.Duration, reason, by string) (*Store.Ban, error) {
+	if (username == "") == (ip == "") {
+		return nil, status.Error(codes.InvalidArgument, "ban either a username or an IP address")
+	}
+	var user *Store.User
+	if username != "" {
+		var err error
+		if user, err = s.store.GetUser(ctx, username); err != nil {
+			slog.ErrorContext(ctx, "looking up user failed", "error", err)
+			return nil, status.Error(codes.Internal, "looking up user failed")
+		}
+		if user == nil {
+			return nil, status.Errorf(codes.NotFound, "user %q not found", username)
+		}
+	} else if net.ParseIP(ip) == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%q is not an IP address", ip)
+	}
+
+	ban := &Store.Ban{Username: username, IP: ip, Reason: reason, By: by, CreatedAt: time.Now()}
+	if d > 0 {
+		until := ban.CreatedAt.Add(d)
+		ban.Until = &until
+	}
+	if err := s.store.AddBan(ctx, ban); err != nil {
+		slog.ErrorContext(ctx, "storing ban failed", "error", err)
+		return nil, status.Error(codes.Internal, "storing ban failed")
+	}
+	slog.InfoContext(ctx, "banned", "username", username, "ip", ip, "duration", d, "reason", reason, "by", by)
+
+	msg := "banned"
+	if reason != "" {
+		msg += ": " + reason
+	}
+	if user != nil {
+		userID := strconv.Itoa(int(user.ID))
+		s.auth.RevokeUser(userID)
+		s.closeStreams(func(l *liveStream) bool { return l.userID() == userID }, msg)
+	} else {
+		s.mu.Lock()
+		s.cacheIPBan(ban)
+		s.mu.Unlock()
+		s.closeStreams(func(l *liveStream) bool { return l.ip == ip }, msg)
+		if s.legacy != nil {
+			s.legacy.KickIP(ip, msg)
+		}
+	}
+	return ban, nil
+}
+
+// Unban 删除用户或 IP 的所有封禁，返回删除的条数。用户也可以立即重新登录
+func (s *ModerationService) Unban(ctx context.Context, username, ip, by string) (int64, error) {
+	n, err := s.store.DeleteBans(ctx, username, ip)
+	if err != nil {
+		slog.ErrorContext(ctx, "deleting bans failed", "error", err)
+		return 0, status.Error(codes.Internal, "deleting bans failed")
+	}
+	s.mu.Lock()
+	if username != "" {
+		delete(s.kicked, username)
+	} else {
+		delete(s.ipBans, ip)
+	}
+	s.mu.Unlock()
+	if n > 0 {
+		slog.InfoContext(ctx, "unbanned", "username", username, "ip", ip, "by", by)
+	}
+	return n, nil
+}
+
+// RegisterCommands 注册 /kick、/ban、/banip、/unban 和 /whitelist
+func (s *ModerationService) RegisterCommands(r *commands.Registry) error {
+	for _, c := range []*commands.Command{{
+		Name: "kick",
+		Help: "disconnects a user, player or legacy client",
+		Args: []commands.Arg{
+			{Name: "target", Type: commands.String},
+			{Name: "reason", Type: commands.Text, Optional: true},
+		},
+		Run: func(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+			if _, err := s.Kick(ctx, args.String("target"), args.String("reason")); err != nil {
+				return "", err
+			}
+			return "kicked " + args.String("target"), nil
+		},
+	}, {
+		Name: "ban",
+		Help: "bans a user, for good without a duration",
+		Args: []commands.Arg{
+			{Name: "user", Type: commands.String},
+			{Name: "duration", Type: commands.Duration, Optional: true},
+			{Name: "reason", Type: commands.Text, Optional: true},
+		},
+		Run: func(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+			ban, err := s.Ban(ctx, args.String("user"), "", args.Duration("duration"), args.String("reason"), sender.Name)
+			if err != nil {
+				return "", err
+			}
+			return describeBan(ban), nil
+		},
+	}, {
+		Name: "banip",
+		Help: "bans an IP address, for good without a duration",
+		Args: []commands.Arg{
+			{Name: "ip", Type: commands.String},
+			{Name: "duration", Type: commands.Duration, Optional: true},
+			{Name: "reason", Type: commands.Text, Optional: true},
+		},
+		Run: func(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+			ban, err := s.Ban(ctx, "", args.String("ip"), args.Duration("duration"), args.String("reason"), sender.Name)
+			if err != nil {
+				return "", err
+			}
+			return describeBan(ban), nil
+		},
+	}, {
+		Name: "unban",
+		Help: "lifts the bans of a user or IP address",
+		Args: []commands.Arg{{Name: "target", Type: commands.String}},
+		Run: func(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+			username, ip := args.String("target"), ""
+			if net.ParseIP(username) != nil {
+				username, ip = "", username
+			}
+			n, err := s.Unban(ctx, username, ip, sender.Name)
+			if err != nil {
+				return "", err
+			}
+			if n == 0 {
+				return args.String("target") + " was not banned", nil
+			}
+			return "unbanned " + args.String("target"), nil
+		},
+	}, {
+		Name: "whitelist",
+		Help: "turns the whitelist on or off, or adds, removes or lists users",
+		Args: []commands.Arg{
+			{Name: "action", Type: commands.Choice("action", "on", "off", "add", "remove", "list")},
+			{Name: "user", Type: commands.String, Optional: true},
+		},
+		Run: s.cmdWhitelist,
+	}} {
+		if err := r.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func describeBan(b *Store.Ban) string {
+	target := b.Username
+	if target == "" {
+		target = b.IP
+	}
+	if b.Until == nil {
+		return "banned " + target
+	}
+	return "banned " + target + " until " + b.Until.Format(time.RFC3339)
+}
+
+func (s *ModerationService) cmdWhitelist(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+	action, username := args.String("action"), args.String("user")
+	switch action {
+	case "on", "off":
+		s.SetWhitelist(action == "on")
+		return "whitelist " + action, nil
+	case "list":
+		entries, err := s.store.Whitelist(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "reading whitelist failed", "error", err)
+			return "", status.Error(codes.Internal, "reading whitelist failed")
+		}
+		names := make([]string, len(entries))
+		for i, e := range entries {
+			names[i] = e.Username
+		}
+		state := "off"
+		if s.whitelistEnabled() {
+			state = "on"
+		}
+		return fmt.Sprintf("whitelist is %s, %d users: %s", state, len(names), strings.Join(names, ", ")), nil
+	}
+	if username == "" {
+		return "", status.Errorf(codes.InvalidArgument, "name the user to %s", action)
+	}
+	if action == "add" {
+		if err := s.store.AddToWhitelist(ctx, username, sender.Name); err != nil {
+			slog.ErrorContext(ctx, "adding to whitelist failed", "error", err)
+			return "", status.Error(codes.Internal, "adding to whitelist failed")
+		}
+		slog.InfoContext(ctx, "user whitelisted", "username", username, "by", sender.Name)
+		return "whitelisted " + username, nil
+	}
+	removed, err := s.store.RemoveFromWhitelist(ctx, username)
+	if err != nil {
+		slog.ErrorContext(ctx, "removing from whitelist failed", "error", err)
+		return "", status.Error(codes.Internal, "removing from whitelist failed")
+	}
+	if !removed {
+		return username + " was not whitelisted", nil
+	}
+	slog.InfoContext(ctx, "user removed from whitelist", "username", username, "by", sender.Name)
+	return "removed " + username + " from the whitelist", nil
+}
+
+// RegisterAdminRoutes 注册管理路由，auth 限定为管理员。
+// GET /admin/bans 列出封禁，POST /admin/bans {username 或 ip, duration, reason} 封禁，
+// DELETE /admin/bans?username=&ip= 解封；GET /admin/whitelist 列出白名单，
+// PUT 和 DELETE /admin/whitelist/:username 加入和移出；POST /admin/kick {target, reason} 踢出
+func (s *ModerationService) RegisterAdminRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	r.GET("/admin/bans", auth, s.httpBans)
+	r.POST("/admin/bans", auth, s.httpBan)
+	r.DELETE("/admin/bans", auth, s.httpUnban)
+	r.GET("/admin/whitelist", auth, s.httpWhitelist)
+	r.PUT("/admin/whitelist/:username", auth, s.httpWhitelistAdd)
+	r.DELETE("/admin/whitelist/:username", auth, s.httpWhitelistRemove)
+	r.POST("/admin/kick", auth, s.httpKick)
+}
+
+func (s *ModerationService) httpBans(c *gin.Context) {
+	bans, err := s.store.Bans(c.Request.Context())
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "reading bans failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "reading bans failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"bans": bans})
+}
+
+// httpBan 封禁，duration 如 "24h"，为空时永久封禁
+func (s *ModerationService) httpBan(c *gin.Context) {
+	var req struct {
+		Username string `json:"username"`
+		IP       string `json:"ip"`
+		Duration string `json:"duration"`
+		Reason   string `json:"reason"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	var d time.Duration
+	if req.Duration != "" {
+		var err error
+		if d, err = time.ParseDuration(req.Duration); err != nil || d < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid duration"})
+			return
+		}
+	}
+	ban, err := s.Ban(c.Request.Context(), req.Username, req.IP, d, req.Reason, "admin")
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ban)
+}
+
+func (s *ModerationService) httpUnban(c *gin.Context) {
+	n, err := s.Unban(c.Request.Context(), c.Query("username"), c.Query("ip"), "admin")
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": n})
+}
+
+func (s *ModerationService) httpWhitelist(c *gin.Context) {
+	entries, err := s.store.Whitelist(c.Request.Context())
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "reading whitelist failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "reading whitelist failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"enabled": s.whitelistEnabled(), "users": entries})
+}
+
+func (s *ModerationService) httpWhitelistAdd(c *gin.Context) {
+	username := c.Param("username")
+	if err := s.store.AddToWhitelist(c.Request.Context(), username, "admin"); err != nil {
+		slog.ErrorContext(c.Request.Context(), "adding to whitelist failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "adding to whitelist failed"})
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "user whitelisted", "username", username, "by", "admin")
+	c.JSON(http.StatusOK, gin.H{"username": username})
+}
+
+func (s *ModerationService) httpWhitelistRemove(c *gin.Context) {
+	removed, err := s.store.RemoveFromWhitelist(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "removing from whitelist failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "removing from whitelist failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
+
+func (s *ModerationService) httpKick(c *gin.Context) {
+	var req struct {
+		Target string `json:"target"`
+		Reason string `json:"reason"`
+	}
+	if err := c.BindJSON(&req); err != nil || req.Target == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	n, err := s.Kick(c.Request.Context(), req.Target, req.Reason)
+	if err != nil {
+		httpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"connections": n})
+}
@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	authpb "github.com/perlinson/gocraft-server/proto/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serverStream 是只有上下文的 grpc.ServerStream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// 测试封禁、白名单和踢出，封禁和白名单对登录和注册都有效
+func TestModerationService(t *testing.T) {
+	ctx := logging.WithClientIP(context.Background(), "10.0.0.1")
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	authService := services.NewAuthService(s)
+	moderation := services.NewModerationService(s, authService, services.NewPlayerService(nil))
+	authService.SetAdmission(moderation.Admit)
+	alice, err := s.CreateUser(ctx, "alice", "secret", "")
+	require.NoError(t, err)
+	_, err = s.CreateUser(ctx, "bob", "secret", "")
+	require.NoError(t, err)
+	login := func(name string) error {
+		_, err := authService.Login(ctx, &authpb.LoginRequest{Username: name, Password: "secret"})
+		return err
+	}
+	resp, err := authService.Login(ctx, &authpb.LoginRequest{Username: "alice", Password: "secret"})
+	require.NoError(t, err)
+	token := resp.Token
+
+	// 被封禁用户的流以封禁原因结束，之后不能登录
+	streamCtx := logging.WithUserID(ctx, strconv.Itoa(int(alice.ID)))
+	started, done := make(chan struct{}), make(chan error, 1)
+	go func() {
+		done <- moderation.StreamServerInterceptor()(nil, &serverStream{ctx: streamCtx}, &grpc.StreamServerInfo{},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				close(started)
+				<-ss.Context().Done()
+				return ss.Context().Err()
+			})
+	}()
+	<-started
+	_, err = moderation.Kick(ctx, "alice", "")
+	require.NoError(t, err)
+	err = <-done
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, "kicked", status.Convert(err).Message())
+	_, err = moderation.Kick(ctx, "alice", "")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// 被踢出的用户的令牌失效，一段时间内不能重新登录
+	_, ok := authService.ResolveUser(token)
+	assert.False(t, ok)
+	assert.Equal(t, codes.PermissionDenied, status.Code(login("alice")))
+
+	_, err = moderation.Ban(ctx, "alice", "", time.Hour, "griefing", "admin")
+	require.NoError(t, err)
+	err = login("alice")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "griefing")
+	n, err := moderation.Unban(ctx, "alice", "", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, login("alice"))
+
+	// IP 封禁对所有用户和旧版协议都有效
+	_, err = moderation.Ban(ctx, "", "10.0.0.1", 0, "", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(login("bob")))
+	_, err = authService.Register(ctx, &services.RegisterRequest{Username: "carol", Password: "secret"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Error(t, moderation.AdmitLegacy("10.0.0.1"))
+	assert.NoError(t, moderation.AdmitLegacy("10.0.0.2"))
+	unary := moderation.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err = unary(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = unary(logging.WithClientIP(ctx, "10.0.0.2"), nil, &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+	_, err = moderation.Unban(ctx, "", "10.0.0.1", "admin")
+	require.NoError(t, err)
+
+	// 白名单模式只允许白名单中的用户和管理员，拒绝旧版协议
+	moderation.SetWhitelist(true)
+	require.NoError(t, s.AddToWhitelist(ctx, "alice", "admin"))
+	assert.NoError(t, login("alice"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(login("bob")))
+	assert.Error(t, moderation.AdmitLegacy("10.0.0.2"))
+	_, err = authService.Register(ctx, &services.RegisterRequest{Username: "carol", Password: "secret"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, s.AddToWhitelist(ctx, "carol", "admin"))
+	_, err = authService.Register(ctx, &services.RegisterRequest{Username: "carol", Password: "secret"})
+	assert.NoError(t, err)
+	_, err = s.SetUserRole(ctx, "bob", store.AdminRole)
+	require.NoError(t, err)
+	assert.NoError(t, login("bob"))
+}
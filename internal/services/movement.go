@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/blocks"
+	"github.com/perlinson/gocraft-server/internal/metrics"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+)
+
+// MovementRules 限制 UpdateState 接受的移动。速度的单位是方块每秒，
+// Tolerance 以方块为单位，容忍网络抖动
+type MovementRules struct {
+	MaxSpeed     float64
+	MaxRiseSpeed float64
+	MaxFallSpeed float64
+	Tolerance    float64
+}
+
+// 违规移动的种类
+const (
+	violationInvalid = "invalid"
+	violationSpeed   = "speed"
+	violationRise    = "rise"
+	violationFall    = "fall"
+	violationNoClip  = "noclip"
+)
+
+// BlockReader 查询方块，*Store.Store 和 *chunkcache.Cache 都实现了它
+type BlockReader interface {
+	GetBlock(world string, id Store.Vec3) (int, error)
+}
+
+// SetMovementRules 开启移动校验。只有设置了 terrain 才检测进入实心方块的移动，
+// 并且只针对其中存储的方块，即玩家放置的方块
+func (s *PlayerService) SetMovementRules(rules MovementRules, terrain BlockReader) {
+	s.rules = &rules
+	s.terrain = terrain
+}
+
+// SetBlockRegistry 设置移动校验判断实心方块使用的方块类型，默认为 gocraft 客户端的类型
+func (s *PlayerService) SetBlockRegistry(r *blocks.Registry) {
+	s.blocks = r
+}
+
+// Violations 返回每个玩家被拒绝的移动次数
+func (s *PlayerService) Violations() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	violations := make(map[string]int, len(s.violations))
+	for id, n := range s.violations {
+		violations[id] = n
+	}
+	return violations
+}
+
+// checkMove 返回在世界 world 中经过 dt 从 prev 移动到 next 的违规，合法时返回 ""。
+// 调用时不能持有 s.mu，可能查询存储
+func (s *PlayerService) checkMove(ctx context.Context, world string, prev, next *playerpb.PlayerState, dt time.Duration) string {
+	for _, v := range []float32{next.X, next.Y, next.Z} {
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return violationInvalid
+		}
+	}
+
+	r := s.rules
+	secs := dt.Seconds()
+	if math.Hypot(float64(next.X-prev.X), float64(next.Z-prev.Z)) > r.MaxSpeed*secs+r.Tolerance {
+		return violationSpeed
+	}
+	dy := float64(next.Y - prev.Y)
+	if dy > r.MaxRiseSpeed*secs+r.Tolerance {
+		return violationRise
+	}
+	if -dy > r.MaxFallSpeed*secs+r.Tolerance {
+		return violationFall
+	}
+
+	// 只有进入新的方块时才查询存储
+	if s.terrain != nil && playerBlock(next) != playerBlock(prev) && s.clips(ctx, world, next) {
+		return violationNoClip
+	}
+	return ""
+}
+
+// clips 判断位于 state 的玩家是否与世界 world 中的实心方块重叠。
+// state 是眼睛的位置，身体占据下面一格
+func (s *PlayerService) clips(ctx context.Context, world string, state *playerpb.PlayerState) bool {
+	eye := playerBlock(state)
+	for _, id := range []Store.Vec3{eye, eye.Down()} {
+		w, err := s.terrain.GetBlock(world, id)
+		if err != nil {
+			// 存储不可用时不能让所有玩家都无法移动
+			slog.WarnContext(ctx, "looking up block for movement check failed", "block", id, "error", err)
+			return false
+		}
+		if s.blocks.Solid(int32(w)) {
+			return true
+		}
+	}
+	return false
+}
+
+// playerBlock 返回位置所在的方块，取整方式与客户端相同
+func playerBlock(state *playerpb.PlayerState) Store.Vec3 {
+	return Store.Vec3{
+		X: int32(math.Round(float64(state.X))),
+		Y: int32(math.Round(float64(state.Y))),
+		Z: int32(math.Round(float64(state.Z))),
+	}
+}
+
+// rejectMove 调用时必须持有 s.mu
+func (s *PlayerService) rejectMove(ctx context.Context, id, violation string) {
+	s.violations[id]++
+	metrics.MovementViolations.WithLabelValues(violation).Inc()
+	slog.InfoContext(ctx, "rejected player move", "player", id, "violation", violation, "violations", s.violations[id])
+}
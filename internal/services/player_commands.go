@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/commands"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 一天中的刻，与 Minecraft 相同每秒 20 刻
+const (
+	ticksPerDay    = 24000
+	ticksPerSecond = 20
+)
+
+// namedTimes 是 /time 接受的时间名称
+var namedTimes = map[string]int64{
+	"day":      1000,
+	"noon":     6000,
+	"night":    13000,
+	"midnight": 18000,
+}
+
+// worldClock 是世界的时间，在 at 时为 ticks
+type worldClock struct {
+	ticks int64
+	at    time.Time
+}
+
+// teleport 是服务器对玩家的移动，客户端在下一次 UpdateState 的响应中得知
+type teleport struct {
+	// state 是新的位置，只切换世界时为 nil
+	state *playerpb.PlayerState
+	// 玩家切换了世界时设置 world
+	world string
+}
+
+// loadSpawns 读取 /setspawn 设置的出生点
+func (s *PlayerService) loadSpawns() {
+	if s.store == nil {
+		return
+	}
+	spawns, err := s.store.WorldSpawns(context.Background())
+	if err != nil {
+		slog.Warn("loading world spawns failed", "error", err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sp := range spawns {
+		s.spawns[sp.World] = &playerpb.PlayerState{X: sp.X, Y: sp.Y, Z: sp.Z}
+	}
+}
+
+// timeOfDay 调用时必须持有 s.mu。服务器启动时所有世界都从日出开始
+func (s *PlayerService) timeOfDay(world string, now time.Time) int32 {
+	c, ok := s.clocks[world]
+	if !ok {
+		c = worldClock{at: s.started}
+	}
+	ticks := c.ticks + now.Sub(c.at).Milliseconds()*ticksPerSecond/1000
+	return int32(ticks % ticksPerDay)
+}
+
+// Teleport 把在线玩家移动到 x, y, z，保持朝向，返回玩家是否在线
+func (s *PlayerService) Teleport(ctx context.Context, id string, x, y, z float32) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.players[id]
+	if !ok {
+		return false
+	}
+	state := &playerpb.PlayerState{X: x, Y: y, Z: z, Rx: prev.Rx, Ry: prev.Ry}
+	s.players[id] = state
+	s.movedAt[id] = s.now()
+	s.teleports[id] = teleport{state: state}
+	s.publish(&playerpb.PlayerEvent{
+		Type:  playerpb.PlayerEvent_UPDATE,
+		Id:    id,
+		State: state,
+		World: s.worldOf(id),
+	})
+	slog.InfoContext(ctx, "player teleported", "player", id, "x", x, "y", y, "z", z)
+	return true
+}
+
+// Kick 移除在线玩家，原因随离开事件发送，返回玩家是否在线
+func (s *PlayerService) Kick(ctx context.Context, id, reason string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.players[id]; !ok {
+		return false
+	}
+	if reason != "" {
+		reason = leaveReasonKicked + ": " + reason
+	} else {
+		reason = leaveReasonKicked
+	}
+	s.remove(id, reason)
+	slog.InfoContext(ctx, "player kicked", "player", id, "reason", reason)
+	return true
+}
+
+// RegisterCommands 注册 /list、/tp、/world、/setspawn 和 /time，
+// /kick 由 ModerationService 注册
+func (s *PlayerService) RegisterCommands(r *commands.Registry) error {
+	player := commands.Enum{Kind: "player", Values: s.playerIDs}
+	world := commands.Enum{Kind: "world", Values: s.worldNames}
+	for _, c := range []*commands.Command{{
+		Name:    "list",
+		Aliases: []string{"who"},
+		Help:    "lists the online players by world",
+		Run:     s.cmdList,
+	}, {
+		Name:    "tp",
+		Aliases: []string{"teleport"},
+		Help:    "teleports your player or another one",
+		Args: []commands.Arg{
+			{Name: "x", Type: commands.Float},
+			{Name: "y", Type: commands.Float},
+			{Name: "z", Type: commands.Float},
+			{Name: "player", Type: player, Optional: true},
+		},
+		Run: s.cmdTeleport,
+	}, {
+		Name: "world",
+		Help: "lists the worlds or moves a player into one",
+		Args: []commands.Arg{
+			{Name: "world", Type: world, Optional: true},
+			{Name: "player", Type: player, Optional: true},
+		},
+		Run: s.cmdWorld,
+	}, {
+		Name: "setspawn",
+		Help: "sets where players enter a world, by default at your position",
+		Args: []commands.Arg{
+			{Name: "x", Type: commands.Float, Optional: true},
+			{Name: "y", Type: commands.Float, Optional: true},
+			{Name: "z", Type: commands.Float, Optional: true},
+			{Name: "world", Type: world, Optional: true},
+		},
+		Run: s.cmdSetSpawn,
+	}, {
+		Name: "time",
+		Help: "shows or sets the time of day: ticks, day, noon, night or midnight",
+		Args: []commands.Arg{
+			{Name: "time", Type: commands.String, Optional: true},
+			{Name: "world", Type: world, Optional: true},
+		},
+		Run: s.cmdTime,
+	}} {
+		if err := r.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PlayerService) playerIDs(ctx context.Context) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.players))
+	for id := range s.players {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (s *PlayerService) worldNames(ctx context.Context) []string {
+	var names []string
+	for _, w := range s.worlds.All() {
+		names = append(names, w.Name)
+	}
+	return names
+}
+
+// target 返回 args 指定的玩家，默认为发送者的玩家
+func target(sender commands.Sender, args commands.Args) (string, error) {
+	if id := args.String("player"); id != "" {
+		return id, nil
+	}
+	if sender.Player == "" {
+		return "", status.Error(codes.InvalidArgument, "you have no player, name one")
+	}
+	return sender.Player, nil
+}
+
+// senderWorld 返回 args 指定的世界，默认为发送者的玩家所在的世界
+func (s *PlayerService) senderWorld(sender commands.Sender, args commands.Args) string {
+	if w := args.String("world"); w != "" {
+		return w
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.worldOf(sender.Player)
+}
+
+func (s *PlayerService) cmdList(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	byWorld := make(map[string][]string)
+	for id := range s.players {
+		byWorld[s.worldOf(id)] = append(byWorld[s.worldOf(id)], id)
+	}
+	lines := []string{fmt.Sprintf("%d players online", len(s.players))}
+	for _, w := range s.worlds.All() {
+		if ids := byWorld[w.Name]; len(ids) > 0 {
+			sort.Strings(ids)
+			lines = append(lines, w.Name+": "+strings.Join(ids, ", "))
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (s *PlayerService) cmdTeleport(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+	id, err := target(sender, args)
+	if err != nil {
+		return "", err
+	}
+	x, y, z := float32(args.Float("x")), float32(args.Float("y")), float32(args.Float("z"))
+	if !s.Teleport(ctx, id, x, y, z) {
+		return "", status.Errorf(codes.NotFound, "player %s is not online", id)
+	}
+	return fmt.Sprintf("teleported %s to %g %g %g", id, x, y, z), nil
+}
+
+func (s *PlayerService) cmdWorld(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+	if !args.Has("world") {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		var lines []string
+		for _, w := range s.worlds.All() {
+			info := s.worldInfo(w)
+			lines = append(lines, fmt.Sprintf("%s: %s generator, %d players", w.Name, w.Generator, info.Players))
+		}
+		return strings.Join(lines, "\n"), nil
+	}
+	id, err := target(sender, args)
+	if err != nil {
+		return "", err
+	}
+	w := s.worlds.Lookup(args.String("world"))
+	if !sender.Console && !w.CanEnter(sender.Role) {
+		return "", status.Errorf(codes.PermissionDenied, "world %s: role %q may not enter", w.Name, sender.Role)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.changeWorld(ctx, id, w.Name) {
+		return fmt.Sprintf("%s is already in %s", id, w.Name), nil
+	}
+	s.teleports[id] = teleport{state: s.spawns[w.Name], world: w.Name}
+	return fmt.Sprintf("moved %s to %s", id, w.Name), nil
+}
+
+func (s *PlayerService) cmdSetSpawn(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+	world := s.senderWorld(sender, args)
+	var spawn *playerpb.PlayerState
+	switch {
+	case args.Has("z"):
+		spawn = &playerpb.PlayerState{X: float32(args.Float("x")), Y: float32(args.Float("y")), Z: float32(args.Float("z"))}
+	case args.Has("x"):
+		return "", status.Error(codes.InvalidArgument, "give all of x, y and z or none")
+	default:
+		w, state, ok := s.position(sender.Player)
+		if !ok {
+			return "", status.Error(codes.InvalidArgument, "you have no player online, give x, y and z")
+		}
+		world, spawn = w, &playerpb.PlayerState{X: state.X, Y: state.Y, Z: state.Z}
+	}
+
+	if s.store != nil {
+		err := s.store.SetWorldSpawn(ctx, &Store.WorldSpawn{World: world, X: spawn.X, Y: spawn.Y, Z: spawn.Z})
+		if err != nil {
+			slog.ErrorContext(ctx, "saving world spawn failed", "error", err)
+			return "", status.Error(codes.Internal, "saving world spawn failed")
+		}
+	}
+	s.mu.Lock()
+	s.spawns[world] = spawn
+	s.mu.Unlock()
+	slog.InfoContext(ctx, "world spawn set", "world", world, "by", sender.Name, "x", spawn.X, "y", spawn.Y, "z", spawn.Z)
+	return fmt.Sprintf("spawn of %s set to %g %g %g", world, spawn.X, spawn.Y, spawn.Z), nil
+}
+
+func (s *PlayerService) cmdTime(ctx context.Context, sender commands.Sender, args commands.Args) (string, error) {
+	world := s.senderWorld(sender, args)
+	now := s.now()
+	if !args.Has("time") {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return fmt.Sprintf("time in %s is %d", world, s.timeOfDay(world, now)), nil
+	}
+	value := strings.ToLower(args.String("time"))
+	ticks, ok := namedTimes[value]
+	if !ok {
+		var err error
+		ticks, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || ticks < 0 || ticks >= ticksPerDay {
+			return "", status.Errorf(codes.InvalidArgument, "time %q is not day, noon, night, midnight or 0 to %d", value, ticksPerDay-1)
+		}
+	}
+	s.mu.Lock()
+	s.clocks[world] = worldClock{ticks: ticks, at: now}
+	s.mu.Unlock()
+	slog.InfoContext(ctx, "world time set", "world", world, "by", sender.Name, "ticks", ticks)
+	return fmt.Sprintf("time in %s set to %d", world, ticks), nil
+}
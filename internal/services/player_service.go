@@ -2,48 +2,388 @@ package services
 
 import (
 	"context"
+	"log/slog"
+	"net/http"
 	"sync"
+	"time"
 
-	playerpb "github.com/perlinson/gocraft-server/internal/proto/player"
+	"github.com/gin-gonic/gin"
+	"github.com/perlinson/gocraft-server/internal/blocks"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	"github.com/perlinson/gocraft-server/internal/worlds"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	leaveReasonRemoved = "removed"
+	leaveReasonIdle    = "idle timeout"
+	leaveReasonWorld   = "changed world"
+	leaveReasonKicked  = "kicked"
 )
 
 type PlayerService struct {
 	playerpb.UnimplementedPlayerServiceServer
-	mu      sync.RWMutex
-	players map[string]*playerpb.PlayerState
+	mu       sync.RWMutex
+	players  map[string]*playerpb.PlayerState
+	lastSeen map[string]time.Time
+	subs     map[chan *playerpb.PlayerEvent]struct{}
+
+	// 玩家所在的世界，没有记录的玩家在默认世界
+	worlds *worlds.Registry
+	world  map[string]string
+	// 玩家的所有者：第一个以该 id 上报状态或切换世界的登录用户，玩家离开后清除
+	owners map[string]string
+
+	// 命令设置的出生点、时间和等待客户端确认的传送
+	spawns    map[string]*playerpb.PlayerState
+	clocks    map[string]worldClock
+	teleports map[string]teleport
+	started   time.Time
+
+	// 移动校验，rules 为 nil 时不校验
+	rules      *MovementRules
+	terrain    BlockReader
+	store      *Store.Store
+	blocks     *blocks.Registry
+	movedAt    map[string]time.Time
+	violations map[string]int
+
+	// now 是服务使用的时钟，测试中可以替换
+	now func() time.Time
 }
 
 func NewPlayerService(server interface{}) *PlayerService {
 	return &PlayerService{
-		players: make(map[string]*playerpb.PlayerState),
+		players:  make(map[string]*playerpb.PlayerState),
+		lastSeen: make(map[string]time.Time),
+		subs:     make(map[chan *playerpb.PlayerEvent]struct{}),
+		worlds:   worlds.Default(),
+		world:    make(map[string]string),
+		owners:   make(map[string]string),
+
+		spawns:    make(map[string]*playerpb.PlayerState),
+		clocks:    make(map[string]worldClock),
+		teleports: make(map[string]teleport),
+		started:   time.Now(),
+
+		blocks:     blocks.Default(),
+		movedAt:    make(map[string]time.Time),
+		violations: make(map[string]int),
+
+		now: time.Now,
 	}
 }
 
-// 实现 gRPC 服务接口
+// SetWorlds 设置玩家可以进入的世界，默认只有默认世界。
+// store 用于查询进入受限世界的玩家的角色
+func (s *PlayerService) SetWorlds(r *worlds.Registry, store *Store.Store) {
+	s.worlds = r
+	s.store = store
+	s.loadSpawns()
+}
+
+// 实现 gRPC 服务接口，只返回和通知同一世界中的玩家
 func (s *PlayerService) UpdateState(ctx context.Context, req *playerpb.UpdateStateRequest) (*playerpb.UpdateStateResponse, error) {
+	now := s.now()
+
+	// 校验移动，存储查询不持有锁
+	violation := ""
+	if s.rules != nil && req.State != nil {
+		s.mu.RLock()
+		prev, movedAt, world := s.players[req.Id], s.movedAt[req.Id], s.worldOf(req.Id)
+		s.mu.RUnlock()
+		if prev != nil {
+			violation = s.checkMove(ctx, world, prev, req.State, now.Sub(movedAt))
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// 更新玩家状态
-	s.players[req.Id] = req.State
-
 	// 准备响应数据
 	resp := &playerpb.UpdateStateResponse{
 		Players: make(map[string]*playerpb.PlayerState),
 	}
 
-	// 收集其他玩家状态（排除自己）
+	// 收集同一世界中其他玩家状态（排除自己）
+	world := s.worldOf(req.Id)
 	for id, state := range s.players {
-		if id != req.Id {
+		if id != req.Id && s.worldOf(id) == world {
 			resp.Players[id] = state
 		}
 	}
+
+	s.lastSeen[req.Id] = now
+	s.claim(ctx, req.Id)
+	// 服务器传送了玩家，客户端要移到传送的位置
+	if t, ok := s.teleports[req.Id]; ok {
+		delete(s.teleports, req.Id)
+		resp.World = t.world
+		if t.state != nil {
+			violation = ""
+			req = &playerpb.UpdateStateRequest{Id: req.Id, State: t.state}
+			resp.Correction = t.state
+		}
+	}
+	if violation != "" {
+		// 拒绝移动，让客户端回到上一个有效位置
+		s.rejectMove(ctx, req.Id, violation)
+		resp.Correction = s.players[req.Id]
+		return resp, nil
+	}
+
+	// 更新玩家状态
+	_, known := s.players[req.Id]
+	s.players[req.Id] = req.State
+	s.movedAt[req.Id] = now
+
+	event := &playerpb.PlayerEvent{
+		Type:  playerpb.PlayerEvent_UPDATE,
+		Id:    req.Id,
+		State: req.State,
+		World: world,
+	}
+	if !known {
+		event.Type = playerpb.PlayerEvent_JOIN
+	}
+	s.publish(event)
 	return resp, nil
 }
 
 func (s *PlayerService) RemovePlayer(ctx context.Context, req *playerpb.RemovePlayerRequest) (*playerpb.RemovePlayerResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	delete(s.players, req.Id)
+	s.remove(req.Id, leaveReasonRemoved)
 	return &playerpb.RemovePlayerResponse{}, nil
 }
+
+// Heartbeat 刷新在线但没有移动的玩家的最后活动时间
+func (s *PlayerService) Heartbeat(ctx context.Context, req *playerpb.HeartbeatRequest) (*playerpb.HeartbeatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.players[req.Id]; !ok {
+		return nil, status.Errorf(codes.NotFound, "player %s is not online", req.Id)
+	}
+	s.lastSeen[req.Id] = s.now()
+	return &playerpb.HeartbeatResponse{}, nil
+}
+
+// StreamEvents 推送订阅者所在世界中其他玩家的加入、更新和离开事件，直到客户端断开
+func (s *PlayerService) StreamEvents(req *playerpb.StreamEventsRequest, stream playerpb.PlayerService_StreamEventsServer) error {
+	ch := make(chan *playerpb.PlayerEvent, 64)
+	s.mu.Lock()
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subs, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case event := <-ch:
+			s.mu.RLock()
+			world := s.worldOf(req.Id)
+			s.mu.RUnlock()
+			if event.Id == req.Id || event.World != world {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ListWorlds 列出所有世界及其中的玩家数
+func (s *PlayerService) ListWorlds(ctx context.Context, req *playerpb.ListWorldsRequest) (*playerpb.ListWorldsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	resp := &playerpb.ListWorldsResponse{}
+	for _, w := range s.worlds.All() {
+		resp.Worlds = append(resp.Worlds, s.worldInfo(w))
+	}
+	return resp, nil
+}
+
+// JoinWorld 在调用者的角色可以进入时把玩家移到另一个世界，只有玩家的所有者和管理员可以移动它。
+// 玩家离开原来的世界，下一次 UpdateState 时加入新的世界，不做移动校验
+func (s *PlayerService) JoinWorld(ctx context.Context, req *playerpb.JoinWorldRequest) (*playerpb.JoinWorldResponse, error) {
+	w, err := worldAccess(ctx, s.worlds, s.store, req.World, (*worlds.World).CanEnter, "enter")
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	owner := s.owners[req.Id]
+	s.mu.RUnlock()
+	if owner != "" && owner != logging.UserID(ctx) {
+		role, err := userRole(ctx, s.store)
+		if err != nil {
+			return nil, err
+		}
+		if role != Store.AdminRole {
+			return nil, status.Errorf(codes.PermissionDenied, "player %s belongs to another user", req.Id)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owners[req.Id] != owner {
+		return nil, status.Errorf(codes.Aborted, "player %s changed owner, try again", req.Id)
+	}
+	s.claim(ctx, req.Id)
+	s.changeWorld(ctx, req.Id, w.Name)
+	return &playerpb.JoinWorldResponse{World: s.worldInfo(w)}, nil
+}
+
+// claim 调用时必须持有 s.mu。玩家 id 还没有所有者时，已登录的调用者成为其所有者
+func (s *PlayerService) claim(ctx context.Context, id string) {
+	if _, ok := s.owners[id]; ok {
+		return
+	}
+	if userID := logging.UserID(ctx); userID != "" {
+		s.owners[id] = userID
+	}
+}
+
+// changeWorld 调用时必须持有 s.mu，返回玩家之前是否在其他世界
+func (s *PlayerService) changeWorld(ctx context.Context, id, world string) bool {
+	if s.worldOf(id) == world {
+		return false
+	}
+	s.leave(id, leaveReasonWorld)
+	s.world[id] = world
+	s.lastSeen[id] = s.now()
+	slog.InfoContext(ctx, "player changed world", "player", id, "world", world)
+	return true
+}
+
+// worldOf 调用时必须持有 s.mu
+func (s *PlayerService) worldOf(id string) string {
+	if world, ok := s.world[id]; ok {
+		return world
+	}
+	return Store.DefaultWorld
+}
+
+// worldInfo 调用时必须持有 s.mu
+func (s *PlayerService) worldInfo(w *worlds.World) *playerpb.World {
+	info := &playerpb.World{
+		Name:      w.Name,
+		Seed:      w.Seed,
+		Generator: w.Generator,
+		Spawn:     s.spawns[w.Name],
+		Time:      s.timeOfDay(w.Name, s.now()),
+	}
+	for id := range s.players {
+		if s.worldOf(id) == w.Name {
+			info.Players++
+		}
+	}
+	return info
+}
+
+// position 返回玩家 id 所在的世界和状态，不在线时 ok 为 false
+func (s *PlayerService) position(id string) (world string, state *playerpb.PlayerState, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, ok = s.players[id]
+	if !ok {
+		return "", nil, false
+	}
+	return s.worldOf(id), state, true
+}
+
+// Players 返回在线玩家的快照
+func (s *PlayerService) Players() map[string]*playerpb.PlayerState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	players := make(map[string]*playerpb.PlayerState, len(s.players))
+	for id, state := range s.players {
+		players[id] = state
+	}
+	return players
+}
+
+// RegisterRoutes 注册 HTTP 路由
+func (s *PlayerService) RegisterRoutes(r *gin.Engine) {
+	r.GET("/api/players", s.httpPlayers)
+}
+
+// RegisterAdminRoutes 注册管理路由，auth 只允许管理员访问
+func (s *PlayerService) RegisterAdminRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	r.GET("/admin/players/violations", auth, s.httpViolations)
+}
+
+// httpViolations 列出每个玩家被拒绝的移动次数
+func (s *PlayerService) httpViolations(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"violations": s.Violations()})
+}
+
+// httpPlayers 列出在线玩家
+func (s *PlayerService) httpPlayers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"players": s.Players()})
+}
+
+// ReapIdle 每隔 interval 移除超过 idle 没有活动的玩家，直到 ctx 结束
+func (s *PlayerService) ReapIdle(ctx context.Context, idle, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := s.now()
+			s.mu.Lock()
+			for id, seen := range s.lastSeen {
+				if now.Sub(seen) > idle {
+					slog.Info("evicting idle player", "player", id, "idle", now.Sub(seen))
+					s.remove(id, leaveReasonIdle)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// remove 调用时必须持有 s.mu，玩家回到默认世界
+func (s *PlayerService) remove(id, reason string) {
+	s.leave(id, reason)
+	delete(s.lastSeen, id)
+	delete(s.world, id)
+	delete(s.teleports, id)
+	delete(s.owners, id)
+}
+
+// leave 调用时必须持有 s.mu
+func (s *PlayerService) leave(id, reason string) {
+	if _, ok := s.players[id]; !ok {
+		return
+	}
+	delete(s.players, id)
+	delete(s.lastSeen, id)
+	delete(s.movedAt, id)
+	s.publish(&playerpb.PlayerEvent{
+		Type:   playerpb.PlayerEvent_LEAVE,
+		Id:     id,
+		Reason: reason,
+		World:  s.worldOf(id),
+	})
+}
+
+// publish 调用时必须持有 s.mu。处理不及的订阅者会丢失事件，而不是阻塞服务
+func (s *PlayerService) publish(event *playerpb.PlayerEvent) {
+	for ch := range s.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/commands"
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	"github.com/perlinson/gocraft-server/internal/worlds"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eventStream struct {
+	playerpb.PlayerService_StreamEventsServer
+	ctx    context.Context
+	events chan *playerpb.PlayerEvent
+}
+
+func (s *eventStream) Context() context.Context { return s.ctx }
+
+func (s *eventStream) Send(event *playerpb.PlayerEvent) error {
+	s.events <- event
+	return nil
+}
+
+// 测试掉线玩家被清理并广播离开事件
+func TestPlayerServiceReapIdle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	playerService := services.NewPlayerService(nil)
+	stream := &eventStream{ctx: ctx, events: make(chan *playerpb.PlayerEvent, 8)}
+	go playerService.StreamEvents(&playerpb.StreamEventsRequest{Id: "watcher"}, stream)
+	require.Eventually(t, func() bool { return playerService.Subscribers() == 1 }, time.Second, time.Millisecond)
+
+	_, err := playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: "crashed", State: &playerpb.PlayerState{Y: 16}})
+	assert.NoError(t, err)
+	event := <-stream.events
+	assert.Equal(t, playerpb.PlayerEvent_JOIN, event.Type)
+
+	go playerService.ReapIdle(ctx, 20*time.Millisecond, 5*time.Millisecond)
+
+	select {
+	case event = <-stream.events:
+		assert.Equal(t, playerpb.PlayerEvent_LEAVE, event.Type)
+		assert.Equal(t, "crashed", event.Id)
+	case <-time.After(time.Second):
+		t.Fatal("no leave event for idle player")
+	}
+
+	resp, err := playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: "watcher", State: &playerpb.PlayerState{}})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Players)
+}
+
+// 测试移动校验：超速与穿墙被拒绝并返回纠正位置
+func TestPlayerServiceMovement(t *testing.T) {
+	ctx := context.Background()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.DB.Create(&store.Block{BlockX: 3, BlockY: 16, BlockZ: 0, BlockType: 1}).Error)
+
+	playerService := services.NewPlayerService(nil)
+	playerService.SetMovementRules(services.MovementRules{MaxSpeed: 10, MaxRiseSpeed: 5, MaxFallSpeed: 50, Tolerance: 0.5}, s)
+	now := time.Now()
+	playerService.SetClock(func() time.Time { return now })
+	update := func(x, y float32) *playerpb.UpdateStateResponse {
+		resp, err := playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: "p", State: &playerpb.PlayerState{X: x, Y: y}})
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.Nil(t, update(0, 16).Correction)
+	now = now.Add(100 * time.Millisecond)
+	assert.Nil(t, update(1, 16).Correction)
+
+	// teleport
+	assert.Equal(t, float32(1), update(50, 16).Correction.GetX())
+	// flying straight up
+	assert.Equal(t, float32(1), update(1, 30).Correction.GetX())
+
+	// walking into the block at x=3
+	now = now.Add(200 * time.Millisecond)
+	assert.Nil(t, update(2, 16).Correction)
+	now = now.Add(100 * time.Millisecond)
+	assert.NotNil(t, update(3, 16).Correction)
+
+	assert.Equal(t, map[string]int{"p": 3}, playerService.Violations())
+	assert.Equal(t, float32(2), playerService.Players()["p"].GetX())
+}
+
+// 测试 /tp、/world、/setspawn、/time 命令和踢出玩家
+func TestPlayerServiceCommands(t *testing.T) {
+	ctx := context.Background()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	playerService := services.NewPlayerService(nil)
+	playerService.SetWorlds(worlds.New(config.WorldConfig{Worlds: []config.NamedWorldConfig{
+		{Name: "creative"},
+		{Name: "vault", Enter: []string{"admin"}},
+	}}), s)
+	registry := commands.NewRegistry(map[string][]string{"builder": {"command.*"}})
+	require.NoError(t, playerService.RegisterCommands(registry))
+	builder := commands.Sender{Name: "bob", Role: "builder", Player: "p1"}
+	run := func(sender commands.Sender, line string) string {
+		out, err := registry.Execute(ctx, sender, line)
+		require.NoError(t, err, line)
+		return out
+	}
+
+	_, err = playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: "p1", State: &playerpb.PlayerState{Y: 16, Rx: 1}})
+	require.NoError(t, err)
+
+	// 传送后客户端的下一次更新被纠正到传送位置
+	run(builder, "/tp 100 20 -5")
+	resp, err := playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: "p1", State: &playerpb.PlayerState{Y: 16}})
+	require.NoError(t, err)
+	assert.Equal(t, &playerpb.PlayerState{X: 100, Y: 20, Z: -5, Rx: 1}, resp.Correction)
+	resp, err = playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: "p1", State: &playerpb.PlayerState{X: 100, Y: 20, Z: -4}})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Correction)
+
+	// 出生点保存在 store 中，换世界的玩家被送到出生点
+	run(commands.ConsoleSender, "/setspawn 1 2 3 creative")
+	spawns, err := s.WorldSpawns(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []store.WorldSpawn{{World: "creative", X: 1, Y: 2, Z: 3}}, spawns)
+	_, err = registry.Execute(ctx, builder, "/world vault")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, "moved p1 to creative", run(builder, "/world creative"))
+	resp, err = playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: "p1", State: &playerpb.PlayerState{X: 100, Y: 20, Z: -4}})
+	require.NoError(t, err)
+	assert.Equal(t, "creative", resp.World)
+	assert.Equal(t, float32(3), resp.Correction.GetZ())
+
+	run(builder, "/time noon")
+	worldList, err := playerService.ListWorlds(ctx, &playerpb.ListWorldsRequest{})
+	require.NoError(t, err)
+	for _, w := range worldList.Worlds {
+		if w.Name == "creative" {
+			assert.InDelta(t, 6000, w.Time, 20)
+			assert.Equal(t, float32(1), w.Spawn.GetX())
+		}
+	}
+	_, err = registry.Execute(ctx, builder, "/time dusk")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	assert.True(t, playerService.Kick(ctx, "p1", "spamming the chat"))
+	assert.Empty(t, playerService.Players())
+	assert.False(t, playerService.Kick(ctx, "p1", ""))
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/perlinson/gocraft-server/internal/logging"
+	Store "github.com/perlinson/gocraft-server/internal/store"
+	"github.com/perlinson/gocraft-server/internal/worlds"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userRole 返回调用者的角色，未登录或 store 为 nil 时为空
+func userRole(ctx context.Context, store *Store.Store) (string, error) {
+	userID := logging.UserID(ctx)
+	if userID == "" || store == nil {
+		return "", nil
+	}
+	id, err := strconv.ParseInt(userID, 10, 32)
+	if err != nil {
+		return "", nil
+	}
+	user, err := store.GetUserByID(ctx, int32(id))
+	if err != nil {
+		slog.ErrorContext(ctx, "looking up user failed", "error", err)
+		return "", status.Error(codes.Internal, "looking up user failed")
+	}
+	if user == nil {
+		return "", nil
+	}
+	return user.Role, nil
+}
+
+// worldAccess 返回名为 name 的世界，allowed 判断调用者的角色能否 action。
+// 只有受限的世界才查询角色
+func worldAccess(ctx context.Context, registry *worlds.Registry, store *Store.Store, name string,
+	allowed func(*worlds.World, string) bool, action string) (*worlds.World, error) {
+	w := registry.Lookup(name)
+	if w == nil {
+		return nil, status.Errorf(codes.NotFound, "world %q does not exist", name)
+	}
+	if allowed(w, "") {
+		return w, nil
+	}
+	role, err := userRole(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "world %s: login required to %s", w.Name, action)
+	}
+	if !allowed(w, role) {
+		return nil, status.Errorf(codes.PermissionDenied, "world %s: role %q may not %s", w.Name, role, action)
+	}
+	return w, nil
+}
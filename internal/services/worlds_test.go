@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
+	"github.com/perlinson/gocraft-server/internal/services"
+	"github.com/perlinson/gocraft-server/internal/store"
+	"github.com/perlinson/gocraft-server/internal/worlds"
+	blockpb "github.com/perlinson/gocraft-server/proto/block"
+	playerpb "github.com/perlinson/gocraft-server/proto/player"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 测试多个世界的方块互相独立、建造和进入权限以及切换世界
+func TestWorlds(t *testing.T) {
+	ctx := context.Background()
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer s.Close()
+	registry := worlds.New(config.WorldConfig{Seed: 1, Worlds: []config.NamedWorldConfig{
+		{Name: "creative", Seed: 2, Generator: "flat", Build: []string{"builder"}},
+		{Name: "arena", Enter: []string{store.AdminRole}},
+	}})
+	blockService := services.NewBlockService(s)
+	blockService.SetWorlds(registry)
+
+	user, err := s.CreateUser(ctx, "bob", "secret", "")
+	require.NoError(t, err)
+	bob := logging.WithUserID(ctx, strconv.Itoa(int(user.ID)))
+
+	// 受限的世界需要登录和角色
+	block := func(ctx context.Context, world string, w int32) error {
+		_, err := blockService.UpdateBlock(ctx, &blockpb.UpdateBlockRequest{X: 1, Y: 2, Z: 3, W: w, World: world})
+		return err
+	}
+	assert.NoError(t, block(ctx, "", 3))
+	assert.Equal(t, codes.NotFound, status.Code(block(ctx, "nether", 3)))
+	assert.Equal(t, codes.Unauthenticated, status.Code(block(ctx, "creative", 4)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(block(bob, "creative", 4)))
+	ok, err := s.SetUserRole(ctx, "bob", "builder")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.NoError(t, block(bob, "creative", 4))
+
+	// 同一位置在两个世界中是不同的方块
+	fetch := func(world string) []*blockpb.Block {
+		resp, err := blockService.FetchChunk(bob, &blockpb.FetchChunkRequest{World: world})
+		require.NoError(t, err)
+		return resp.Blocks
+	}
+	require.Len(t, fetch(store.DefaultWorld), 1)
+	assert.Equal(t, int32(3), fetch(store.DefaultWorld)[0].W)
+	require.Len(t, fetch("creative"), 1)
+	assert.Equal(t, int32(4), fetch("creative")[0].W)
+	_, err = blockService.FetchChunk(bob, &blockpb.FetchChunkRequest{World: "arena"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// 切换世界后只看到同一世界的玩家
+	playerService := services.NewPlayerService(nil)
+	playerService.SetWorlds(registry, s)
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream := &eventStream{ctx: streamCtx, events: make(chan *playerpb.PlayerEvent, 8)}
+	go playerService.StreamEvents(&playerpb.StreamEventsRequest{Id: "watcher"}, stream)
+	require.Eventually(t, func() bool { return playerService.Subscribers() == 1 }, time.Second, time.Millisecond)
+
+	move := func(id string) map[string]*playerpb.PlayerState {
+		resp, err := playerService.UpdateState(ctx, &playerpb.UpdateStateRequest{Id: id, State: &playerpb.PlayerState{Y: 16}})
+		require.NoError(t, err)
+		return resp.Players
+	}
+	move("watcher")
+	assert.Contains(t, move("bob"), "watcher")
+	event := <-stream.events
+	assert.Equal(t, playerpb.PlayerEvent_JOIN, event.Type)
+
+	_, err = playerService.JoinWorld(bob, &playerpb.JoinWorldRequest{Id: "bob", World: "arena"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	joined, err := playerService.JoinWorld(bob, &playerpb.JoinWorldRequest{Id: "bob", World: "creative"})
+	require.NoError(t, err)
+	assert.Equal(t, &playerpb.World{Name: "creative", Seed: 2, Generator: "flat"}, joined.World)
+	event = <-stream.events
+	assert.Equal(t, playerpb.PlayerEvent_LEAVE, event.Type)
+	assert.Equal(t, store.DefaultWorld, event.World)
+
+	assert.Empty(t, move("bob"))
+	assert.Empty(t, move("watcher"))
+	select {
+	case event := <-stream.events:
+		t.Fatalf("unexpected event from another world: %v", event)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	resp, err := playerService.ListWorlds(ctx, &playerpb.ListWorldsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Worlds, 3)
+	assert.Equal(t, "arena", resp.Worlds[0].Name)
+	assert.Equal(t, int32(1), resp.Worlds[1].Players)
+	assert.Equal(t, int64(1), resp.Worlds[2].Seed)
+
+	// 只有玩家的所有者和管理员可以移动它
+	other, err := s.CreateUser(ctx, "alice", "secret", "")
+	require.NoError(t, err)
+	alice := logging.WithUserID(ctx, strconv.Itoa(int(other.ID)))
+	_, err = playerService.JoinWorld(alice, &playerpb.JoinWorldRequest{Id: "bob", World: store.DefaultWorld})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = playerService.JoinWorld(ctx, &playerpb.JoinWorldRequest{Id: "bob", World: store.DefaultWorld})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = playerService.JoinWorld(alice, &playerpb.JoinWorldRequest{Id: "alice", World: "creative"})
+	assert.NoError(t, err)
+	_, err = s.SetUserRole(ctx, "alice", store.AdminRole)
+	require.NoError(t, err)
+	_, err = playerService.JoinWorld(alice, &playerpb.JoinWorldRequest{Id: "bob", World: store.DefaultWorld})
+	assert.NoError(t, err)
+}
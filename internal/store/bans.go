@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ban 禁止用户或 IP 登录直到 Until，Until 为 nil 时永久封禁。
+// Username 和 IP 只设置一个
+type Ban struct {
+	ID       int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	Username string `gorm:"column:username;size:64;index"`
+	IP       string `gorm:"column:ip;size:64;index"`
+	Reason   string `gorm:"column:reason;size:256"`
+	// By 是执行封禁的管理员
+	By        string     `gorm:"column:banned_by;size:64"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	Until     *time.Time `gorm:"column:banned_until"`
+}
+
+// Active 判断封禁在 now 时是否仍然有效
+func (b *Ban) Active(now time.Time) bool {
+	return b != nil && (b.Until == nil || now.Before(*b.Until))
+}
+
+// WhitelistEntry 是白名单中的用户
+type WhitelistEntry struct {
+	Username  string    `gorm:"column:username;size:64;primaryKey"`
+	AddedBy   string    `gorm:"column:added_by;size:64"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// AddBan 保存一条封禁并设置其 ID
+func (s *Store) AddBan(ctx context.Context, b *Ban) error {
+	return s.DB.WithContext(ctx).Create(b).Error
+}
+
+// ActiveBan 返回 now 时对用户 username 或 IP ip 有效、结束最晚的封禁，没有时返回 nil。
+// 空的 username 或 ip 不参与匹配
+func (s *Store) ActiveBan(ctx context.Context, username, ip string, now time.Time) (*Ban, error) {
+	if username == "" && ip == "" {
+		return nil, nil
+	}
+	q := s.DB.WithContext(ctx).Where("banned_until IS NULL OR banned_until > ?", now)
+	switch {
+	case username != "" && ip != "":
+		q = q.Where("username = ? OR ip = ?", username, ip)
+	case username != "":
+		q = q.Where("username = ?", username)
+	default:
+		q = q.Where("ip = ?", ip)
+	}
+	var bans []Ban
+	if err := q.Find(&bans).Error; err != nil {
+		return nil, err
+	}
+	var latest *Ban
+	for i := range bans {
+		b := &bans[i]
+		if latest == nil || b.Until == nil || latest.Until != nil && b.Until.After(*latest.Until) {
+			latest = b
+		}
+		if b.Until == nil {
+			break
+		}
+	}
+	return latest, nil
+}
+
+// Bans 按 ID 倒序返回所有封禁，包括已过期的
+func (s *Store) Bans(ctx context.Context) ([]Ban, error) {
+	var bans []Ban
+	if err := s.DB.WithContext(ctx).Order("id DESC").Find(&bans).Error; err != nil {
+		return nil, err
+	}
+	return bans, nil
+}
+
+// DeleteBans 删除用户 username 或 IP ip 的所有封禁，返回删除的条数
+func (s *Store) DeleteBans(ctx context.Context, username, ip string) (int64, error) {
+	if username == "" && ip == "" {
+		return 0, nil
+	}
+	q := s.DB.WithContext(ctx)
+	if username != "" {
+		q = q.Where("username = ?", username)
+	} else {
+		q = q.Where("ip = ?", ip)
+	}
+	res := q.Delete(&Ban{})
+	return res.RowsAffected, res.Error
+}
+
+// AddToWhitelist 把用户加入白名单，已在白名单中时不变
+func (s *Store) AddToWhitelist(ctx context.Context, username, by string) error {
+	entry := &WhitelistEntry{Username: username, AddedBy: by, CreatedAt: time.Now()}
+	return s.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(entry).Error
+}
+
+// RemoveFromWhitelist 把用户移出白名单，不在白名单中时返回 false
+func (s *Store) RemoveFromWhitelist(ctx context.Context, username string) (bool, error) {
+	res := s.DB.WithContext(ctx).Where("username = ?", username).Delete(&WhitelistEntry{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// Whitelisted 判断用户是否在白名单中
+func (s *Store) Whitelisted(ctx context.Context, username string) (bool, error) {
+	var entry WhitelistEntry
+	err := s.DB.WithContext(ctx).Where("username = ?", username).First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Whitelist 按用户名返回白名单
+func (s *Store) Whitelist(ctx context.Context) ([]WhitelistEntry, error) {
+	var entries []WhitelistEntry
+	if err := s.DB.WithContext(ctx).Order("username").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChatMessage 是聊天记录中的一条消息
+type ChatMessage struct {
+	ID      int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	Time    time.Time `gorm:"column:sent_at;index"`
+	Channel string    `gorm:"column:channel;size:16"`
+	World   string    `gorm:"column:world;size:64"`
+	// Sender 和 Recipient 是用户名，Recipient 只有私聊才有
+	Sender    string `gorm:"column:sender;size:64;index"`
+	Recipient string `gorm:"column:recipient;size:64"`
+	Team      string `gorm:"column:team;size:32"`
+	// Text 是发出的文本，Original 是被过滤前的原文，未过滤时为空
+	Text     string `gorm:"column:text;size:1024"`
+	Original string `gorm:"column:original;size:1024"`
+}
+
+// ChatMute 禁止用户发言直到 Until，Until 为 nil 时永久禁言
+type ChatMute struct {
+	Username string     `gorm:"column:username;size:64;primaryKey"`
+	Until    *time.Time `gorm:"column:muted_until"`
+	Reason   string     `gorm:"column:reason;size:256"`
+	// By 是执行禁言的管理员
+	By string `gorm:"column:muted_by;size:64"`
+}
+
+// Active 判断禁言在 now 时是否仍然有效
+func (m *ChatMute) Active(now time.Time) bool {
+	return m != nil && (m.Until == nil || now.Before(*m.Until))
+}
+
+// LogChatMessage 保存一条聊天消息并设置其 ID
+func (s *Store) LogChatMessage(ctx context.Context, m *ChatMessage) error {
+	return s.DB.WithContext(ctx).Create(m).Error
+}
+
+// ChatLog 按时间倒序返回最多 limit 条 ID 小于 before 的消息，before 为 0 时从最新的开始。
+// sender 不为空时只返回该用户发出的消息
+func (s *Store) ChatLog(ctx context.Context, sender string, before int64, limit int) ([]ChatMessage, error) {
+	q := s.DB.WithContext(ctx).Order("id DESC").Limit(limit)
+	if sender != "" {
+		q = q.Where("sender = ?", sender)
+	}
+	if before > 0 {
+		q = q.Where("id < ?", before)
+	}
+	var messages []ChatMessage
+	if err := q.Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// PruneChatLog 删除 before 之前的消息，返回删除的条数
+func (s *Store) PruneChatLog(ctx context.Context, before time.Time) (int64, error) {
+	res := s.DB.WithContext(ctx).Where("sent_at < ?", before).Delete(&ChatMessage{})
+	return res.RowsAffected, res.Error
+}
+
+// SetChatMute 禁言用户，已禁言时覆盖原来的禁言
+func (s *Store) SetChatMute(ctx context.Context, m *ChatMute) error {
+	return s.DB.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(m).Error
+}
+
+// GetChatMute 返回用户的禁言，没有时返回 nil
+func (s *Store) GetChatMute(ctx context.Context, username string) (*ChatMute, error) {
+	var m ChatMute
+	err := s.DB.WithContext(ctx).Where("username = ?", username).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// DeleteChatMute 解除禁言，用户没有被禁言时返回 false
+func (s *Store) DeleteChatMute(ctx context.Context, username string) (bool, error) {
+	res := s.DB.WithContext(ctx).Where("username = ?", username).Delete(&ChatMute{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// ChatMutes 返回所有禁言，包括已过期的
+func (s *Store) ChatMutes(ctx context.Context) ([]ChatMute, error) {
+	var mutes []ChatMute
+	if err := s.DB.WithContext(ctx).Order("username").Find(&mutes).Error; err != nil {
+		return nil, err
+	}
+	return mutes, nil
+}
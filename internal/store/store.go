@@ -1,61 +1,53 @@
 package store
 
 import (
-	"flag"
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 	"context"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/logging"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"github.com/joho/godotenv"
-)
-
-var (
-	dbpath = flag.String("db", "", "db file name (legacy, not used with MySQL)")
+	"gorm.io/gorm/clause"
 )
 
 // var (
 // 	store *Store
 // )
 
-func InitStore() (*Store, error) {
-	// Load environment variables from .env file
-	err := godotenv.Load()
-	if err != nil {
-		log.Printf("Warning: Error loading .env file: %v", err)
+func InitStore(cfg config.StorageConfig) (*Store, error) {
+	var dialector gorm.Dialector
+	switch cfg.Driver {
+	case "sqlite":
+		slog.Info("opening sqlite database", "path", cfg.Path)
+		dialector = sqlite.Open(cfg.Path)
+	default:
+		// Build MySQL DSN (Data Source Name)
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=%s&loc=%s",
+			cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.Charset, cfg.ParseTime, cfg.Loc)
+
+		slog.Info("connecting to mysql database", "host", cfg.Host, "port", cfg.Port, "name", cfg.Name)
+		dialector = mysql.Open(dsn)
 	}
 
-	// Get MySQL connection parameters from environment variables
-	dbHost := getEnv("DB_HOST", "localhost")
-	dbPort := getEnv("DB_PORT", "3306")
-	dbUser := getEnv("DB_USER", "root")
-	dbPassword := getEnv("DB_PASSWORD", "")
-	dbName := getEnv("DB_NAME", "app_db")
-	dbCharset := getEnv("DB_CHARSET", "utf8mb4")
-	dbParseTime := getEnv("DB_PARSE_TIME", "True")
-	dbLoc := getEnv("DB_LOC", "Local")
-
-	// Build MySQL DSN (Data Source Name)
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=%s&loc=%s",
-		dbUser, dbPassword, dbHost, dbPort, dbName, dbCharset, dbParseTime, dbLoc)
-
-	log.Printf("Connecting to MySQL database at %s:%s/%s", dbHost, dbPort, dbName)
-
-	// Open MySQL connection using GORM
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	// Open database connection using GORM
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logging.GormLogger{}})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to MySQL: %v", err)
+		return nil, fmt.Errorf("failed to connect to %s: %v", cfg.Driver, err)
 	}
 
 	store := &Store{DB: db}
 	// 确保 DB 被正确赋值
 	if store.DB == nil {
-		return nil, fmt.Errorf("DB 未初始化")
+		return nil, fmt.Errorf("database is not initialized")
 	}
 	// Initialize database tables
 	err = store.initTables()
@@ -66,36 +58,44 @@ func InitStore() (*Store, error) {
 	return store, nil
 }
 
-// Helper function to get environment variable with default value
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-	return value
-}
-
 // Store ...
 type Store struct {
 	DB *gorm.DB
 }
 
 type Block struct {
-	ChunkX int32 `gorm:"column:chunk_x"`
-	ChunkZ int32 `gorm:"column:chunk_z"`
-	BlockX int32 `gorm:"column:block_x"`
-	BlockY int32 `gorm:"column:block_y"`
-	BlockZ int32 `gorm:"column:block_z"`
+	// World is the name of the world the block is in.
+	World string `gorm:"column:world;size:64;not null;default:world;index:idx_block_world_chunk;uniqueIndex:idx_block_world_pos"`
+	ChunkX int32 `gorm:"column:chunk_x;index:idx_block_world_chunk"`
+	ChunkZ int32 `gorm:"column:chunk_z;index:idx_block_world_chunk"`
+	BlockX int32 `gorm:"column:block_x;uniqueIndex:idx_block_world_pos"`
+	BlockY int32 `gorm:"column:block_y;uniqueIndex:idx_block_world_pos"`
+	BlockZ int32 `gorm:"column:block_z;uniqueIndex:idx_block_world_pos"`
 	BlockType int32 `gorm:"column:block_type"`
+	// Version is the chunk version the block was written with.
+	Version string `gorm:"column:version"`
 }
 
 type Chunk struct {
-	ChunkX int32 `gorm:"column:chunk_x"`
-	ChunkY int32 `gorm:"column:chunk_y"`
-	ChunkZ int32 `gorm:"column:chunk_z"`
+	World string `gorm:"column:world;size:64;not null;default:world;uniqueIndex:idx_chunk_world_pos"`
+	ChunkX int32 `gorm:"column:chunk_x;uniqueIndex:idx_chunk_world_pos"`
+	ChunkY int32 `gorm:"column:chunk_y;uniqueIndex:idx_chunk_world_pos"`
+	ChunkZ int32 `gorm:"column:chunk_z;uniqueIndex:idx_chunk_world_pos"`
 	Version string `gorm:"column:version"`
 }
 
+// The tables have no primary key, writes upsert on these unique indexes.
+var (
+	blockConflict = clause.OnConflict{
+		Columns:   []clause.Column{{Name: "world"}, {Name: "block_x"}, {Name: "block_y"}, {Name: "block_z"}},
+		DoUpdates: clause.AssignmentColumns([]string{"chunk_x", "chunk_z", "block_type", "version"}),
+	}
+	chunkConflict = clause.OnConflict{
+		Columns:   []clause.Column{{Name: "world"}, {Name: "chunk_x"}, {Name: "chunk_y"}, {Name: "chunk_z"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version"}),
+	}
+)
+
 type Camera struct {
 	ID int32 `gorm:"column:id"`
 	X float32 `gorm:"column:x"`
@@ -110,11 +110,30 @@ type User struct {
 	Username string `gorm:"column:username"`
 	Password string `gorm:"column:password"`
 	Email string `gorm:"column:email"`
+	// Role decides what the user may do, e.g. how large region edits are.
+	Role string `gorm:"column:role;default:player"`
 }
 
+// Roles with a special meaning. DefaultRole is the role of newly
+// registered users.
+const (
+	DefaultRole = "player"
+	AdminRole   = "admin"
+)
+
 func (s *Store) initTables() error {
+	// 旧版本用 Save 写入没有主键的表，同一位置可能有多行，建唯一索引前删除重复的行
+	err := s.dedupe(&Block{}, "idx_block_world_pos", "block_x", "block_y", "block_z")
+	if err != nil {
+		return err
+	}
+	err = s.dedupe(&Chunk{}, "idx_chunk_world_pos", "chunk_x", "chunk_y", "chunk_z")
+	if err != nil {
+		return err
+	}
+
 	// Create blocks table
-	err := s.DB.AutoMigrate(&Block{})
+	err = s.DB.AutoMigrate(&Block{})
 	if err != nil {
 		return err
 	}
@@ -125,6 +144,18 @@ func (s *Store) initTables() error {
 		return err
 	}
 
+	// 旧的唯一索引不含世界，会阻止其他世界写入相同坐标
+	for _, old := range []struct {
+		model interface{}
+		name  string
+	}{{&Block{}, "idx_block_pos"}, {&Block{}, "idx_block_chunk"}, {&Chunk{}, "idx_chunk_pos"}} {
+		if s.DB.Migrator().HasIndex(old.model, old.name) {
+			if err := s.DB.Migrator().DropIndex(old.model, old.name); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Create camera table
 	err = s.DB.AutoMigrate(&Camera{})
 	if err != nil {
@@ -151,6 +182,24 @@ func (s *Store) initTables() error {
 		return err
 	}
 
+	// 聊天记录和禁言
+	err = s.DB.AutoMigrate(&ChatMessage{}, &ChatMute{})
+	if err != nil {
+		return err
+	}
+
+	// 世界出生点
+	err = s.DB.AutoMigrate(&WorldSpawn{})
+	if err != nil {
+		return err
+	}
+
+	// 封禁和白名单
+	err = s.DB.AutoMigrate(&Ban{}, &WhitelistEntry{})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -161,40 +210,362 @@ func (s *Store) UserExists(ctx context.Context, username string) (bool, error) {
 	return count > 0, err
 }
 
-// CreateUser 创建新用户
-func (s *Store) CreateUser(ctx context.Context, username, password, email string) error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// dedupe 在唯一索引 index 建立前，让每个位置（世界和 columns）只保留最新的一行：
+// 版本最大的行，版本相同时是数据库最后返回的行，即没有主键时最后写入的行。
+// 表不存在或索引已存在时什么也不做
+func (s *Store) dedupe(model interface{}, index string, columns ...string) error {
+	m := s.DB.Migrator()
+	if !m.HasTable(model) || m.HasIndex(model, index) {
+		return nil
+	}
+	if m.HasColumn(model, "world") {
+		columns = append([]string{"world"}, columns...)
+	}
+	hasVersion := m.HasColumn(model, "version")
+
+	var groups []map[string]interface{}
+	err := s.DB.Model(model).Select(columns).Group(strings.Join(columns, ", ")).Having("COUNT(*) > 1").Find(&groups).Error
 	if err != nil {
 		return err
 	}
-	user := User{Username: username, Password: string(hashedPassword), Email: email}
-	return s.DB.Create(&user).Error
+	if len(groups) == 0 {
+		return nil
+	}
+	slog.Warn("removing duplicate rows before adding unique index", "index", index, "positions", len(groups))
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		for _, group := range groups {
+			var rows []map[string]interface{}
+			if err := tx.Model(model).Where(group).Find(&rows).Error; err != nil {
+				return err
+			}
+			newest := rows[0]
+			for _, row := range rows[1:] {
+				if !hasVersion || !olderVersion(row["version"], newest["version"]) {
+					newest = row
+				}
+			}
+			if err := tx.Where(group).Delete(model).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(model).Create(newest).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// olderVersion 判断版本 a 是否早于 b，版本是十六进制的时间戳，空版本最早
+func olderVersion(a, b interface{}) bool {
+	va, vb := versionString(a), versionString(b)
+	if len(va) != len(vb) {
+		return len(va) < len(vb)
+	}
+	return va < vb
+}
+
+// versionString 返回扫描到 map 中的版本，MySQL 驱动可能返回 []byte
+func versionString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
+
+// CreateUser 创建新用户，password 为明文，保存前加密
+func (s *Store) CreateUser(ctx context.Context, username, password, email string) (*User, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user := &User{Username: username, Password: string(hashedPassword), Email: email, Role: DefaultRole}
+	if err := s.DB.WithContext(ctx).Create(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUser 按用户名查找用户，不存在时返回 nil
+func (s *Store) GetUser(ctx context.Context, username string) (*User, error) {
+	var user User
+	err := s.DB.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserByID 按 ID 查找用户，不存在时返回 nil
+func (s *Store) GetUserByID(ctx context.Context, id int32) (*User, error) {
+	var user User
+	err := s.DB.WithContext(ctx).Where("id = ?", id).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetUserRole 修改用户角色，用户不存在时返回 false
+func (s *Store) SetUserRole(ctx context.Context, username, role string) (bool, error) {
+	res := s.DB.WithContext(ctx).Model(&User{}).Where("username = ?", username).Update("role", role)
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.Error == nil, res.Error
+	}
+	// MySQL 不计入值未变化的行
+	return s.UserExists(ctx, username)
+}
+
+// DefaultWorld is the world of servers with a single world, and of rows
+// stored before there were several.
+const DefaultWorld = "world"
+
+// worldName maps "" to the default world.
+func worldName(world string) string {
+	if world == "" {
+		return DefaultWorld
+	}
+	return world
+}
+
+// Condition is a precondition of UpdateBlock, the zero value always
+// holds.
+type Condition struct {
+	// ChunkVersion, if set, must be the version of the block's chunk.
+	ChunkVersion *string
+	// BlockVersion, if set, must be the version of the block, "" if it was
+	// never written.
+	BlockVersion *string
+}
+
+// ConflictError is returned by UpdateBlock when its Condition does not
+// hold. It carries the current state.
+type ConflictError struct {
+	ChunkVersion string
+	// Block is the stored block, the zero Block with the position set if
+	// there is none.
+	Block Block
 }
 
-func (s *Store) UpdateBlock(id Vec3, w int) error {
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("block (%d, %d, %d) was changed concurrently, chunk version %q, block version %q",
+		e.Block.BlockX, e.Block.BlockY, e.Block.BlockZ, e.ChunkVersion, e.Block.Version)
+}
+
+// UpdateBlock stores block id of world and sets the version of every
+// chunk the change affects in the same transaction, see
+// Vec3.AffectedChunks. It returns those chunks. A failed cond returns a
+// *ConflictError. An empty world is the default world, here and in the
+// other methods taking one.
+func (s *Store) UpdateBlock(world string, id Vec3, w int, version string, cond Condition) ([]Vec3, error) {
+	world = worldName(world)
 	// Get chunk coordinates
 	cid := id.Chunkid()
+	chunks := id.AffectedChunks()
 
-	// Log the update
-	log.Printf("put %v -> %d", id, w)
+	// Log the update, this is the hot path
+	slog.Debug("put block", "world", world, "block", id, "type", w, "version", version)
 
-	// Begin transaction
-	tx := s.DB.Begin()
-	defer func() {
-		if tx.Error != nil {
-			tx.Rollback()
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if cond.ChunkVersion != nil || cond.BlockVersion != nil {
+			if err := checkCondition(tx, world, id, cond); err != nil {
+				return err
+			}
 		}
-	}()
 
-	// Insert or update block
-	block := Block{ChunkX: cid.X, ChunkZ: cid.Z, BlockX: id.X, BlockY: id.Y, BlockZ: id.Z, BlockType: int32(w)}
-	err := tx.Save(&block).Error
+		// Insert or update block
+		block := Block{World: world, ChunkX: cid.X, ChunkZ: cid.Z, BlockX: id.X, BlockY: id.Y, BlockZ: id.Z, BlockType: int32(w), Version: version}
+		if err := tx.Clauses(blockConflict).Create(&block).Error; err != nil {
+			return err
+		}
+		return updateChunkVersions(tx, world, chunks, version)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+// writeBatchSize bounds the rows of one INSERT statement.
+const writeBatchSize = 500
+
+// Unset in UpdateBlocks deletes the stored block, so the generated
+// terrain shows again.
+const Unset = -1
+
+// UpdateBlocks stores a batch of blocks of world in one transaction and
+// sets the version of every affected chunk once. It returns those chunks,
+// sorted.
+func (s *Store) UpdateBlocks(world string, blocks map[Vec3]int, version string) ([]Vec3, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	world = worldName(world)
+	slog.Debug("put blocks", "world", world, "blocks", len(blocks), "version", version)
+
+	rows := make([]Block, 0, len(blocks))
+	var unset [][]interface{}
+	for id, w := range blocks {
+		if w == Unset {
+			unset = append(unset, []interface{}{id.X, id.Y, id.Z})
+		} else {
+			cid := id.Chunkid()
+			rows = append(rows, Block{World: world, ChunkX: cid.X, ChunkZ: cid.Z, BlockX: id.X, BlockY: id.Y, BlockZ: id.Z, BlockType: int32(w), Version: version})
+		}
+	}
+	chunks := AffectedChunksOf(blocks)
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := writeBlocks(tx, world, rows, unset); err != nil {
+			return err
+		}
+		return updateChunkVersions(tx, world, chunks, version)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+// writeBlocks upserts rows and deletes the blocks at the x, y, z triples
+// of unset.
+func writeBlocks(tx *gorm.DB, world string, rows []Block, unset [][]interface{}) error {
+	if len(rows) > 0 {
+		if err := tx.Clauses(blockConflict).CreateInBatches(rows, writeBatchSize).Error; err != nil {
+			return err
+		}
+	}
+	for start := 0; start < len(unset); start += writeBatchSize {
+		end := min(start+writeBatchSize, len(unset))
+		if err := tx.Where("world = ? AND (block_x, block_y, block_z) IN ?", world, unset[start:end]).Delete(&Block{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AffectedChunksOf returns the chunks changing blocks affects, see
+// Vec3.AffectedChunks, sorted by X and Z.
+func AffectedChunksOf(blocks map[Vec3]int) []Vec3 {
+	affected := make(map[Vec3]bool)
+	for id := range blocks {
+		for _, c := range id.AffectedChunks() {
+			affected[c] = true
+		}
+	}
+	chunks := make([]Vec3, 0, len(affected))
+	for c := range affected {
+		chunks = append(chunks, c)
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		if chunks[i].X != chunks[j].X {
+			return chunks[i].X < chunks[j].X
+		}
+		return chunks[i].Z < chunks[j].Z
+	})
+	return chunks
+}
+
+// ChunkWrite is a chunk changed in memory, see WriteChunks.
+type ChunkWrite struct {
+	World   string
+	ID      Vec3
+	Version string
+	// Blocks are the changed blocks with the version each was written
+	// with. A BlockType of Unset deletes the block.
+	Blocks []Block
+}
+
+// WriteChunks stores the versions and changed blocks of chunks in one
+// transaction. Unlike UpdateBlocks the blocks keep their own versions.
+func (s *Store) WriteChunks(chunks []ChunkWrite) error {
+	if len(chunks) == 0 {
+		return nil
+	}
+	rows := make(map[string][]Block)
+	unset := make(map[string][][]interface{})
+	versions := make([]Chunk, 0, len(chunks))
+	for _, c := range chunks {
+		world := worldName(c.World)
+		for _, b := range c.Blocks {
+			if b.BlockType == Unset {
+				unset[world] = append(unset[world], []interface{}{b.BlockX, b.BlockY, b.BlockZ})
+				continue
+			}
+			b.World = world
+			rows[world] = append(rows[world], b)
+		}
+		versions = append(versions, Chunk{World: world, ChunkX: c.ID.X, ChunkY: c.ID.Y, ChunkZ: c.ID.Z, Version: c.Version})
+	}
+	slog.Debug("write chunks", "chunks", len(chunks))
+
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		for world := range rows {
+			if err := writeBlocks(tx, world, rows[world], nil); err != nil {
+				return err
+			}
+		}
+		for world := range unset {
+			if err := writeBlocks(tx, world, nil, unset[world]); err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(chunkConflict).CreateInBatches(versions, writeBatchSize).Error
+	})
+}
+
+func updateChunkVersions(tx *gorm.DB, world string, chunks []Vec3, version string) error {
+	rows := make([]Chunk, len(chunks))
+	for i, c := range chunks {
+		rows[i] = Chunk{World: world, ChunkX: c.X, ChunkY: c.Y, ChunkZ: c.Z, Version: version}
+	}
+	return tx.Clauses(chunkConflict).CreateInBatches(rows, writeBatchSize).Error
+}
+
+// GetBlock returns the type of the block at id in world, 0 (air) if none
+// was stored.
+func (s *Store) GetBlock(world string, id Vec3) (int, error) {
+	var block Block
+	err := s.DB.Where("world = ? AND block_x = ? AND block_y = ? AND block_z = ?", worldName(world), id.X, id.Y, id.Z).First(&block).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int(block.BlockType), nil
+}
+
+// checkCondition locks the chunk and block rows, where the database
+// supports it, and compares their versions.
+func checkCondition(tx *gorm.DB, world string, id Vec3, cond Condition) error {
+	cid := id.Chunkid()
+	forUpdate := clause.Locking{Strength: "UPDATE"}
+
+	var chunk Chunk
+	err := tx.Clauses(forUpdate).Where("world = ? AND chunk_x = ? AND chunk_y = ? AND chunk_z = ?", world, cid.X, cid.Y, cid.Z).Limit(1).Find(&chunk).Error
+	if err != nil {
+		return err
+	}
+	block := Block{World: world, ChunkX: cid.X, ChunkZ: cid.Z, BlockX: id.X, BlockY: id.Y, BlockZ: id.Z}
+	err = tx.Clauses(forUpdate).Where("world = ? AND block_x = ? AND block_y = ? AND block_z = ?", world, id.X, id.Y, id.Z).Limit(1).Find(&block).Error
 	if err != nil {
 		return err
 	}
 
-	// Commit transaction
-	return tx.Commit().Error
+	if (cond.ChunkVersion != nil && *cond.ChunkVersion != chunk.Version) ||
+		(cond.BlockVersion != nil && *cond.BlockVersion != block.Version) {
+		return &ConflictError{ChunkVersion: chunk.Version, Block: block}
+	}
+	return nil
 }
 
 func (s *Store) UpdateCamera(x, y, z, rx, ry float32) error {
@@ -209,7 +580,7 @@ func (s *Store) GetCamera() (x, y, z, rx, ry float32) {
 	var camera Camera
 	err := s.DB.Where("id = ?", 1).First(&camera).Error
 	if err != nil {
-		log.Printf("Error getting camera: %v", err)
+		slog.Error("error getting camera", "error", err)
 	} else {
 		x = camera.X
 		y = camera.Y
@@ -221,9 +592,28 @@ func (s *Store) GetCamera() (x, y, z, rx, ry float32) {
 	return
 }
 
-func (s *Store) RangeBlocks(id Vec3, f func(bid Vec3, w int)) error {
+// RangeBlocks calls f with every stored block of chunk id in world and
+// the version it was written with.
+func (s *Store) RangeBlocks(world string, id Vec3, f func(bid Vec3, w int, version string)) error {
+	var blocks []Block
+	err := s.DB.Where("world = ? AND chunk_x = ? AND chunk_z = ?", worldName(world), id.X, id.Z).Find(&blocks).Error
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		f(Vec3{block.BlockX, block.BlockY, block.BlockZ}, int(block.BlockType), block.Version)
+	}
+
+	return nil
+}
+
+// RangeRegion calls f with every stored block of world in the cuboid from
+// min to max, both inclusive.
+func (s *Store) RangeRegion(world string, min, max Vec3, f func(bid Vec3, w int)) error {
 	var blocks []Block
-	err := s.DB.Where("chunk_x = ? AND chunk_z = ?", id.X, id.Z).Find(&blocks).Error
+	err := s.DB.Where("world = ? AND block_x BETWEEN ? AND ? AND block_y BETWEEN ? AND ? AND block_z BETWEEN ? AND ?",
+		worldName(world), min.X, max.X, min.Y, max.Y, min.Z, max.Z).Find(&blocks).Error
 	if err != nil {
 		return err
 	}
@@ -235,31 +625,56 @@ func (s *Store) RangeBlocks(id Vec3, f func(bid Vec3, w int)) error {
 	return nil
 }
 
-func (s *Store) UpdateChunkVersion(id Vec3, version string) error {
-	chunk := Chunk{ChunkX: id.X, ChunkY: id.Y, ChunkZ: id.Z, Version: version}
-	return s.DB.Save(&chunk).Error
+// LoadChunk returns the version and the stored blocks of chunk id in
+// world. Unlike GetChunkVersion it reports errors.
+func (s *Store) LoadChunk(world string, id Vec3) (string, []Block, error) {
+	world = worldName(world)
+	var chunk Chunk
+	err := s.DB.Where("world = ? AND chunk_x = ? AND chunk_y = ? AND chunk_z = ?", world, id.X, id.Y, id.Z).Limit(1).Find(&chunk).Error
+	if err != nil {
+		return "", nil, err
+	}
+	var blocks []Block
+	if err := s.DB.Where("world = ? AND chunk_x = ? AND chunk_z = ?", world, id.X, id.Z).Find(&blocks).Error; err != nil {
+		return "", nil, err
+	}
+	return chunk.Version, blocks, nil
+}
+
+func (s *Store) UpdateChunkVersion(world string, id Vec3, version string) error {
+	chunk := Chunk{World: worldName(world), ChunkX: id.X, ChunkY: id.Y, ChunkZ: id.Z, Version: version}
+	return s.DB.Clauses(chunkConflict).Create(&chunk).Error
 }
 
-func (s *Store) GetChunkVersion(id Vec3) string {
+func (s *Store) GetChunkVersion(world string, id Vec3) string {
 	var chunk Chunk
-	err := s.DB.Where("chunk_x = ? AND chunk_y = ? AND chunk_z = ?", id.X, id.Y, id.Z).First(&chunk).Error
+	err := s.DB.Where("world = ? AND chunk_x = ? AND chunk_y = ? AND chunk_z = ?", worldName(world), id.X, id.Y, id.Z).First(&chunk).Error
 	if err != nil {
 		// If no version found, return empty string
 		if err == gorm.ErrRecordNotFound {
 			return ""
 		}
-		log.Printf("Error getting chunk version: %v", err)
+		slog.Error("error getting chunk version", "world", world, "chunk", id, "error", err)
 		return ""
 	}
 
 	return chunk.Version
 }
 
+// Ping checks that the database is reachable.
+func (s *Store) Ping(ctx context.Context) error {
+	db, err := s.DB.DB()
+	if err != nil {
+		return err
+	}
+	return db.PingContext(ctx)
+}
+
 func (s *Store) Close() {
 	if s.DB != nil {
 		db, err := s.DB.DB()
 		if err != nil {
-			log.Printf("Error closing database connection: %v", err)
+			slog.Error("error closing database connection", "error", err)
 		} else {
 			db.Close()
 		}
@@ -313,3 +728,34 @@ func (v Vec3) Chunkid() Vec3 {
 	}
 	return Vec3{x / ChunkWidth, 0, z / ChunkWidth}
 }
+
+// AffectedChunks returns the chunk of block v followed by the neighbour
+// chunks it borders on, whose meshes and lighting depend on it too. This
+// is the set gocraft marks dirty when a block changes.
+func (v Vec3) AffectedChunks() []Vec3 {
+	cid := v.Chunkid()
+	chunks := []Vec3{cid}
+	for _, n := range []Vec3{v.Left(), v.Right(), v.Front(), v.Back()} {
+		if nid := n.Chunkid(); nid != cid {
+			chunks = append(chunks, nid)
+		}
+	}
+	return chunks
+}
+
+// ParseVec3 parses a position written as "x,y,z".
+func ParseVec3(s string) (Vec3, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return Vec3{}, fmt.Errorf("position %q is not x,y,z", s)
+	}
+	var v [3]int32
+	for i, p := range parts {
+		n, err := strconv.ParseInt(strings.TrimSpace(p), 10, 32)
+		if err != nil {
+			return Vec3{}, fmt.Errorf("position %q is not x,y,z", s)
+		}
+		v[i] = int32(n)
+	}
+	return Vec3{v[0], v[1], v[2]}, nil
+}
@@ -0,0 +1,50 @@
+package store_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Databases written before the unique indexes existed hold several rows
+// per position; opening them keeps the newest row of each.
+func TestInitStoreRemovesDuplicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	require.NoError(t, err)
+	for _, stmt := range []string{
+		"CREATE TABLE blocks (chunk_x integer, chunk_z integer, block_x integer, block_y integer, block_z integer, block_type integer)",
+		"INSERT INTO blocks VALUES (0, 0, 1, 2, 3, 1), (0, 0, 1, 2, 3, 2), (0, 0, 4, 5, 6, 7), (0, 0, 1, 2, 3, 3)",
+		"CREATE TABLE chunks (chunk_x integer, chunk_y integer, chunk_z integer, version text)",
+		"INSERT INTO chunks VALUES (0, 0, 0, 'ff'), (0, 0, 0, '100'), (0, 0, 0, 'fe'), (1, 0, 0, 'a')",
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	s, err := store.InitStore(config.StorageConfig{Driver: "sqlite", Path: path})
+	require.NoError(t, err)
+	defer s.Close()
+
+	var blocks []store.Block
+	require.NoError(t, s.DB.Order("block_x").Find(&blocks).Error)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, int32(3), blocks[0].BlockType)
+	assert.Equal(t, "world", blocks[0].World)
+	assert.Equal(t, int32(7), blocks[1].BlockType)
+
+	var chunks []store.Chunk
+	require.NoError(t, s.DB.Order("chunk_x").Find(&chunks).Error)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, "100", chunks[0].Version)
+	assert.Equal(t, "a", chunks[1].Version)
+	assert.True(t, s.DB.Migrator().HasIndex(&store.Block{}, "idx_block_world_pos"))
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+)
+
+// WorldSpawn 是世界的出生点，用 /setspawn 设置
+type WorldSpawn struct {
+	World string  `gorm:"column:world;size:64;primaryKey"`
+	X     float32 `gorm:"column:x"`
+	Y     float32 `gorm:"column:y"`
+	Z     float32 `gorm:"column:z"`
+}
+
+// SetWorldSpawn 设置世界的出生点，覆盖原来的出生点
+func (s *Store) SetWorldSpawn(ctx context.Context, spawn *WorldSpawn) error {
+	return s.DB.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(spawn).Error
+}
+
+// WorldSpawns 返回所有设置过的出生点
+func (s *Store) WorldSpawns(ctx context.Context) ([]WorldSpawn, error) {
+	var spawns []WorldSpawn
+	if err := s.DB.WithContext(ctx).Order("world").Find(&spawns).Error; err != nil {
+		return nil, err
+	}
+	return spawns, nil
+}
@@ -0,0 +1,97 @@
+// Package worlds holds the named worlds a server hosts. Each world has its
+// own blocks, seed, generator and permissions; the store keys blocks and
+// chunks by world name.
+package worlds
+
+import (
+	"sort"
+
+	"github.com/perlinson/gocraft-server/internal/config"
+	"github.com/perlinson/gocraft-server/internal/store"
+)
+
+// DefaultGenerator is the generator of worlds that don't name one, the
+// terrain of the gocraft client.
+const DefaultGenerator = "default"
+
+// World is a named world.
+type World struct {
+	Name      string
+	Seed      int64
+	Generator string
+	build     map[string]bool
+	enter     map[string]bool
+}
+
+// CanBuild reports whether players with role may change blocks.
+func (w *World) CanBuild(role string) bool {
+	return w.build == nil || w.build[role]
+}
+
+// CanEnter reports whether players with role may join the world.
+func (w *World) CanEnter(role string) bool {
+	return w.enter == nil || w.enter[role]
+}
+
+// Registry is an immutable set of worlds, always including the default
+// world.
+type Registry struct {
+	byName map[string]*World
+}
+
+// New builds the registry of the worlds in cfg. The default world uses
+// cfg.Seed unless cfg.Worlds configures it.
+func New(cfg config.WorldConfig) *Registry {
+	r := &Registry{byName: map[string]*World{
+		store.DefaultWorld: {Name: store.DefaultWorld, Seed: cfg.Seed, Generator: DefaultGenerator},
+	}}
+	for _, c := range cfg.Worlds {
+		w := &World{
+			Name:      c.Name,
+			Seed:      c.Seed,
+			Generator: c.Generator,
+			build:     roles(c.Build),
+			enter:     roles(c.Enter),
+		}
+		if w.Generator == "" {
+			w.Generator = DefaultGenerator
+		}
+		r.byName[c.Name] = w
+	}
+	return r
+}
+
+// Default returns a registry holding only the default world.
+func Default() *Registry {
+	return New(config.WorldConfig{})
+}
+
+func roles(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	m := make(map[string]bool, len(names))
+	for _, n := range names {
+		m[n] = true
+	}
+	return m
+}
+
+// Lookup returns the world called name, the default world for "". It
+// returns nil for unknown worlds.
+func (r *Registry) Lookup(name string) *World {
+	if name == "" {
+		name = store.DefaultWorld
+	}
+	return r.byName[name]
+}
+
+// All returns the worlds sorted by name.
+func (r *Registry) All() []*World {
+	all := make([]*World, 0, len(r.byName))
+	for _, w := range r.byName {
+		all = append(all, w)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
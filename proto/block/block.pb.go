@@ -0,0 +1,1666 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v5.29.3
+// source: block.proto
+
+package block
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Precondition makes the update conditional on version. A failed
+// precondition returns ABORTED with an UpdateConflict detail.
+type UpdateBlockRequest_Precondition int32
+
+const (
+	// NONE ignores version, the last writer wins.
+	UpdateBlockRequest_NONE UpdateBlockRequest_Precondition = 0
+	// CHUNK requires version to be the current version of chunk (p, q).
+	UpdateBlockRequest_CHUNK UpdateBlockRequest_Precondition = 1
+	// BLOCK requires version to be the version of the block, empty if
+	// it was never written.
+	UpdateBlockRequest_BLOCK UpdateBlockRequest_Precondition = 2
+)
+
+// Enum value maps for UpdateBlockRequest_Precondition.
+var (
+	UpdateBlockRequest_Precondition_name = map[int32]string{
+		0: "NONE",
+		1: "CHUNK",
+		2: "BLOCK",
+	}
+	UpdateBlockRequest_Precondition_value = map[string]int32{
+		"NONE":  0,
+		"CHUNK": 1,
+		"BLOCK": 2,
+	}
+)
+
+func (x UpdateBlockRequest_Precondition) Enum() *UpdateBlockRequest_Precondition {
+	p := new(UpdateBlockRequest_Precondition)
+	*p = x
+	return p
+}
+
+func (x UpdateBlockRequest_Precondition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UpdateBlockRequest_Precondition) Descriptor() protoreflect.EnumDescriptor {
+	return file_block_proto_enumTypes[0].Descriptor()
+}
+
+func (UpdateBlockRequest_Precondition) Type() protoreflect.EnumType {
+	return &file_block_proto_enumTypes[0]
+}
+
+func (x UpdateBlockRequest_Precondition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UpdateBlockRequest_Precondition.Descriptor instead.
+func (UpdateBlockRequest_Precondition) EnumDescriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type ViewUpdate_Type int32
+
+const (
+	// LOAD brings a chunk into view with all its stored blocks.
+	ViewUpdate_LOAD ViewUpdate_Type = 0
+	// UNLOAD tells that a chunk left the view, no more edits follow.
+	ViewUpdate_UNLOAD ViewUpdate_Type = 1
+	// UPDATE carries an edit of a chunk in view.
+	ViewUpdate_UPDATE ViewUpdate_Type = 2
+)
+
+// Enum value maps for ViewUpdate_Type.
+var (
+	ViewUpdate_Type_name = map[int32]string{
+		0: "LOAD",
+		1: "UNLOAD",
+		2: "UPDATE",
+	}
+	ViewUpdate_Type_value = map[string]int32{
+		"LOAD":   0,
+		"UNLOAD": 1,
+		"UPDATE": 2,
+	}
+)
+
+func (x ViewUpdate_Type) Enum() *ViewUpdate_Type {
+	p := new(ViewUpdate_Type)
+	*p = x
+	return p
+}
+
+func (x ViewUpdate_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ViewUpdate_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_block_proto_enumTypes[1].Descriptor()
+}
+
+func (ViewUpdate_Type) Type() protoreflect.EnumType {
+	return &file_block_proto_enumTypes[1]
+}
+
+func (x ViewUpdate_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ViewUpdate_Type.Descriptor instead.
+func (ViewUpdate_Type) EnumDescriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{16, 0}
+}
+
+type ChunkRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	P       int32                  `protobuf:"varint,1,opt,name=p,proto3" json:"p,omitempty"`
+	Q       int32                  `protobuf:"varint,2,opt,name=q,proto3" json:"q,omitempty"`
+	Version string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// world is the name of the world, empty for the default world.
+	World         string `protobuf:"bytes,4,opt,name=world,proto3" json:"world,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChunkRequest) Reset() {
+	*x = ChunkRequest{}
+	mi := &file_block_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkRequest) ProtoMessage() {}
+
+func (x *ChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkRequest.ProtoReflect.Descriptor instead.
+func (*ChunkRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ChunkRequest) GetP() int32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+func (x *ChunkRequest) GetQ() int32 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+func (x *ChunkRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ChunkRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+type ChunkUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	P     int32                  `protobuf:"varint,1,opt,name=p,proto3" json:"p,omitempty"`
+	Q     int32                  `protobuf:"varint,2,opt,name=q,proto3" json:"q,omitempty"`
+	// blocks are the changed blocks as x, y, z, w quadruples. It is empty
+	// when only the version changed, e.g. for a block on the border of a
+	// neighbour chunk. w is -1 for a block reset to the generated terrain.
+	Blocks        []int32 `protobuf:"varint,3,rep,packed,name=blocks,proto3" json:"blocks,omitempty"`
+	Version       string  `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChunkUpdate) Reset() {
+	*x = ChunkUpdate{}
+	mi := &file_block_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkUpdate) ProtoMessage() {}
+
+func (x *ChunkUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkUpdate.ProtoReflect.Descriptor instead.
+func (*ChunkUpdate) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChunkUpdate) GetP() int32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+func (x *ChunkUpdate) GetQ() int32 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+func (x *ChunkUpdate) GetBlocks() []int32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *ChunkUpdate) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type FetchChunkRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	P       int32                  `protobuf:"varint,1,opt,name=p,proto3" json:"p,omitempty"`
+	Q       int32                  `protobuf:"varint,2,opt,name=q,proto3" json:"q,omitempty"`
+	Version string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// world is the name of the world, empty for the default world.
+	World         string `protobuf:"bytes,4,opt,name=world,proto3" json:"world,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchChunkRequest) Reset() {
+	*x = FetchChunkRequest{}
+	mi := &file_block_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchChunkRequest) ProtoMessage() {}
+
+func (x *FetchChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchChunkRequest.ProtoReflect.Descriptor instead.
+func (*FetchChunkRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FetchChunkRequest) GetP() int32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+func (x *FetchChunkRequest) GetQ() int32 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+func (x *FetchChunkRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *FetchChunkRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+type Block struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	X     int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y     int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z     int32                  `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"`
+	W     int32                  `protobuf:"varint,4,opt,name=w,proto3" json:"w,omitempty"`
+	// version is the chunk version the block was last written with.
+	Version       string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_block_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Block) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Block) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Block) GetZ() int32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *Block) GetW() int32 {
+	if x != nil {
+		return x.W
+	}
+	return 0
+}
+
+func (x *Block) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type FetchChunkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*Block               `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchChunkResponse) Reset() {
+	*x = FetchChunkResponse{}
+	mi := &file_block_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchChunkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchChunkResponse) ProtoMessage() {}
+
+func (x *FetchChunkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchChunkResponse.ProtoReflect.Descriptor instead.
+func (*FetchChunkResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FetchChunkResponse) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *FetchChunkResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type UpdateBlockRequest struct {
+	state        protoimpl.MessageState          `protogen:"open.v1"`
+	Id           string                          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	P            int32                           `protobuf:"varint,2,opt,name=p,proto3" json:"p,omitempty"`
+	Q            int32                           `protobuf:"varint,3,opt,name=q,proto3" json:"q,omitempty"`
+	X            int32                           `protobuf:"varint,4,opt,name=x,proto3" json:"x,omitempty"`
+	Y            int32                           `protobuf:"varint,5,opt,name=y,proto3" json:"y,omitempty"`
+	Z            int32                           `protobuf:"varint,6,opt,name=z,proto3" json:"z,omitempty"`
+	W            int32                           `protobuf:"varint,7,opt,name=w,proto3" json:"w,omitempty"`
+	Version      string                          `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	Precondition UpdateBlockRequest_Precondition `protobuf:"varint,9,opt,name=precondition,proto3,enum=block.UpdateBlockRequest_Precondition" json:"precondition,omitempty"`
+	// world is the name of the world, empty for the default world.
+	World         string `protobuf:"bytes,10,opt,name=world,proto3" json:"world,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBlockRequest) Reset() {
+	*x = UpdateBlockRequest{}
+	mi := &file_block_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlockRequest) ProtoMessage() {}
+
+func (x *UpdateBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlockRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBlockRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateBlockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateBlockRequest) GetP() int32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+func (x *UpdateBlockRequest) GetQ() int32 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+func (x *UpdateBlockRequest) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *UpdateBlockRequest) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *UpdateBlockRequest) GetZ() int32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *UpdateBlockRequest) GetW() int32 {
+	if x != nil {
+		return x.W
+	}
+	return 0
+}
+
+func (x *UpdateBlockRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpdateBlockRequest) GetPrecondition() UpdateBlockRequest_Precondition {
+	if x != nil {
+		return x.Precondition
+	}
+	return UpdateBlockRequest_NONE
+}
+
+func (x *UpdateBlockRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+// UpdateConflict is the current state sent with a failed conditional
+// update, so the client can reconcile.
+type UpdateConflict struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ChunkVersion string                 `protobuf:"bytes,1,opt,name=chunk_version,json=chunkVersion,proto3" json:"chunk_version,omitempty"`
+	// block is the stored block, w = 0 and no version if there is none.
+	Block         *Block `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateConflict) Reset() {
+	*x = UpdateConflict{}
+	mi := &file_block_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConflict) ProtoMessage() {}
+
+func (x *UpdateConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConflict.ProtoReflect.Descriptor instead.
+func (*UpdateConflict) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateConflict) GetChunkVersion() string {
+	if x != nil {
+		return x.ChunkVersion
+	}
+	return ""
+}
+
+func (x *UpdateConflict) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+type ChunkID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	P             int32                  `protobuf:"varint,1,opt,name=p,proto3" json:"p,omitempty"`
+	Q             int32                  `protobuf:"varint,2,opt,name=q,proto3" json:"q,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChunkID) Reset() {
+	*x = ChunkID{}
+	mi := &file_block_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkID) ProtoMessage() {}
+
+func (x *ChunkID) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkID.ProtoReflect.Descriptor instead.
+func (*ChunkID) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ChunkID) GetP() int32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+func (x *ChunkID) GetQ() int32 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+type UpdateBlockResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Version string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// neighbors are the chunks next to the block's chunk that it borders
+	// on. They changed to the same version and must be refetched too.
+	Neighbors     []*ChunkID `protobuf:"bytes,2,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBlockResponse) Reset() {
+	*x = UpdateBlockResponse{}
+	mi := &file_block_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlockResponse) ProtoMessage() {}
+
+func (x *UpdateBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlockResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBlockResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateBlockResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpdateBlockResponse) GetNeighbors() []*ChunkID {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+type BlockType struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// solid blocks stop players, the others can be walked through.
+	Solid       bool `protobuf:"varint,3,opt,name=solid,proto3" json:"solid,omitempty"`
+	Transparent bool `protobuf:"varint,4,opt,name=transparent,proto3" json:"transparent,omitempty"`
+	// light is the emitted light level, 0 to 15.
+	Light    int32   `protobuf:"varint,5,opt,name=light,proto3" json:"light,omitempty"`
+	Hardness float32 `protobuf:"fixed32,6,opt,name=hardness,proto3" json:"hardness,omitempty"`
+	// placeable is false for blocks players may not place, e.g. clouds.
+	Placeable     bool `protobuf:"varint,7,opt,name=placeable,proto3" json:"placeable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockType) Reset() {
+	*x = BlockType{}
+	mi := &file_block_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockType) ProtoMessage() {}
+
+func (x *BlockType) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockType.ProtoReflect.Descriptor instead.
+func (*BlockType) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BlockType) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BlockType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BlockType) GetSolid() bool {
+	if x != nil {
+		return x.Solid
+	}
+	return false
+}
+
+func (x *BlockType) GetTransparent() bool {
+	if x != nil {
+		return x.Transparent
+	}
+	return false
+}
+
+func (x *BlockType) GetLight() int32 {
+	if x != nil {
+		return x.Light
+	}
+	return 0
+}
+
+func (x *BlockType) GetHardness() float32 {
+	if x != nil {
+		return x.Hardness
+	}
+	return 0
+}
+
+func (x *BlockType) GetPlaceable() bool {
+	if x != nil {
+		return x.Placeable
+	}
+	return false
+}
+
+// ListBlockTypesRequest carries the registry version the client has
+// cached, types are only sent when it differs.
+type ListBlockTypesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockTypesRequest) Reset() {
+	*x = ListBlockTypesRequest{}
+	mi := &file_block_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockTypesRequest) ProtoMessage() {}
+
+func (x *ListBlockTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockTypesRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockTypesRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListBlockTypesRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type ListBlockTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*BlockType           `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockTypesResponse) Reset() {
+	*x = ListBlockTypesResponse{}
+	mi := &file_block_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockTypesResponse) ProtoMessage() {}
+
+func (x *ListBlockTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockTypesResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListBlockTypesResponse) GetTypes() []*BlockType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ListBlockTypesResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type UpdateBlocksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// blocks are the x, y, z and w to write, their version is ignored. A
+	// position given twice gets the last w.
+	Blocks []*Block `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// world is the name of the world, empty for the default world.
+	World         string `protobuf:"bytes,2,opt,name=world,proto3" json:"world,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBlocksRequest) Reset() {
+	*x = UpdateBlocksRequest{}
+	mi := &file_block_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlocksRequest) ProtoMessage() {}
+
+func (x *UpdateBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlocksRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateBlocksRequest) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *UpdateBlocksRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+type UpdateBlocksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version is the new version of all chunks.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// chunks are the chunks the batch changed or borders on.
+	Chunks        []*ChunkID `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBlocksResponse) Reset() {
+	*x = UpdateBlocksResponse{}
+	mi := &file_block_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBlocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlocksResponse) ProtoMessage() {}
+
+func (x *UpdateBlocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlocksResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBlocksResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateBlocksResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpdateBlocksResponse) GetChunks() []*ChunkID {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+type StreamViewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id names the view for UpdateView, e.g. the player id. A new stream
+	// with the same id replaces the old one.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// world is the name of the world, empty for the default world.
+	World string `protobuf:"bytes,2,opt,name=world,proto3" json:"world,omitempty"`
+	// x and z are the position the view starts at, in blocks.
+	X float32 `protobuf:"fixed32,3,opt,name=x,proto3" json:"x,omitempty"`
+	Z float32 `protobuf:"fixed32,4,opt,name=z,proto3" json:"z,omitempty"`
+	// distance is the view distance in chunks, chunks whose distance to
+	// the chunk of the position is at most distance are in view.
+	Distance int32 `protobuf:"varint,5,opt,name=distance,proto3" json:"distance,omitempty"`
+	// cached are the chunks the client has cached. The first time they
+	// come into view they are loaded without blocks if their version is
+	// still current.
+	Cached        []*CachedChunk `protobuf:"bytes,6,rep,name=cached,proto3" json:"cached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamViewRequest) Reset() {
+	*x = StreamViewRequest{}
+	mi := &file_block_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamViewRequest) ProtoMessage() {}
+
+func (x *StreamViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamViewRequest.ProtoReflect.Descriptor instead.
+func (*StreamViewRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StreamViewRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+func (x *StreamViewRequest) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *StreamViewRequest) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *StreamViewRequest) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *StreamViewRequest) GetCached() []*CachedChunk {
+	if x != nil {
+		return x.Cached
+	}
+	return nil
+}
+
+type CachedChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	P             int32                  `protobuf:"varint,1,opt,name=p,proto3" json:"p,omitempty"`
+	Q             int32                  `protobuf:"varint,2,opt,name=q,proto3" json:"q,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CachedChunk) Reset() {
+	*x = CachedChunk{}
+	mi := &file_block_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedChunk) ProtoMessage() {}
+
+func (x *CachedChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedChunk.ProtoReflect.Descriptor instead.
+func (*CachedChunk) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CachedChunk) GetP() int32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+func (x *CachedChunk) GetQ() int32 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+func (x *CachedChunk) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type ViewUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  ViewUpdate_Type        `protobuf:"varint,1,opt,name=type,proto3,enum=block.ViewUpdate_Type" json:"type,omitempty"`
+	P     int32                  `protobuf:"varint,2,opt,name=p,proto3" json:"p,omitempty"`
+	Q     int32                  `protobuf:"varint,3,opt,name=q,proto3" json:"q,omitempty"`
+	// version is the chunk version after a LOAD or UPDATE.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// blocks are the stored blocks of a LOAD. They are omitted when the
+	// cached version of the request is current, cached is true then.
+	Blocks []*Block `protobuf:"bytes,5,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	Cached bool     `protobuf:"varint,6,opt,name=cached,proto3" json:"cached,omitempty"`
+	// changes are the changed blocks of an UPDATE as x, y, z, w
+	// quadruples, like ChunkUpdate.blocks.
+	Changes       []int32 `protobuf:"varint,7,rep,packed,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewUpdate) Reset() {
+	*x = ViewUpdate{}
+	mi := &file_block_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewUpdate) ProtoMessage() {}
+
+func (x *ViewUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewUpdate.ProtoReflect.Descriptor instead.
+func (*ViewUpdate) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ViewUpdate) GetType() ViewUpdate_Type {
+	if x != nil {
+		return x.Type
+	}
+	return ViewUpdate_LOAD
+}
+
+func (x *ViewUpdate) GetP() int32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+func (x *ViewUpdate) GetQ() int32 {
+	if x != nil {
+		return x.Q
+	}
+	return 0
+}
+
+func (x *ViewUpdate) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ViewUpdate) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *ViewUpdate) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *ViewUpdate) GetChanges() []int32 {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type UpdateViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	X             float32                `protobuf:"fixed32,2,opt,name=x,proto3" json:"x,omitempty"`
+	Z             float32                `protobuf:"fixed32,3,opt,name=z,proto3" json:"z,omitempty"`
+	Distance      int32                  `protobuf:"varint,4,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateViewRequest) Reset() {
+	*x = UpdateViewRequest{}
+	mi := &file_block_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateViewRequest) ProtoMessage() {}
+
+func (x *UpdateViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateViewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateViewRequest) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateViewRequest) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *UpdateViewRequest) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *UpdateViewRequest) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type UpdateViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateViewResponse) Reset() {
+	*x = UpdateViewResponse{}
+	mi := &file_block_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateViewResponse) ProtoMessage() {}
+
+func (x *UpdateViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_block_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateViewResponse.ProtoReflect.Descriptor instead.
+func (*UpdateViewResponse) Descriptor() ([]byte, []int) {
+	return file_block_proto_rawDescGZIP(), []int{18}
+}
+
+var File_block_proto protoreflect.FileDescriptor
+
+var file_block_proto_rawDesc = string([]byte{
+	0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x5a, 0x0a, 0x0c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x71,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f,
+	0x72, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64,
+	0x22, 0x5b, 0x0a, 0x0b, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x0c, 0x0a, 0x01, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a,
+	0x01, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5f, 0x0a,
+	0x11, 0x46, 0x65, 0x74, 0x63, 0x68, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x70,
+	0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x71, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x22, 0x59,
+	0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01,
+	0x7a, 0x12, 0x0c, 0x0a, 0x01, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x77, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x54, 0x0a, 0x12, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x24, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0xa4, 0x02, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x01, 0x71, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78,
+	0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x12, 0x0c,
+	0x0a, 0x01, 0x7a, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x7a, 0x12, 0x0c, 0x0a, 0x01,
+	0x77, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x77, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x22, 0x2e, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x43, 0x48, 0x55, 0x4e, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x42,
+	0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x02, 0x22, 0x59, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a,
+	0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x22, 0x25, 0x0a, 0x07, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x44, 0x12, 0x0c, 0x0a, 0x01,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x71, 0x22, 0x5d, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x09, 0x6e, 0x65, 0x69,
+	0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x44, 0x52, 0x09, 0x6e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x22, 0xb7, 0x01, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6f, 0x6c,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x6f, 0x6c, 0x69, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x61, 0x72, 0x64, 0x6e,
+	0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x68, 0x61, 0x72, 0x64, 0x6e,
+	0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x61, 0x62, 0x6c,
+	0x65, 0x22, 0x31, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5a, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26,
+	0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x51, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x6f,
+	0x72, 0x6c, 0x64, 0x22, 0x58, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x49, 0x44, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x22, 0x9d, 0x01,
+	0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x01, 0x7a, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x2a, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x22, 0x43, 0x0a,
+	0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x0c, 0x0a, 0x01,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0xf0, 0x01, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x16, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a,
+	0x01, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01, 0x71,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x28, 0x0a, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x4f, 0x41, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a,
+	0x06, 0x55, 0x4e, 0x4c, 0x4f, 0x41, 0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44,
+	0x41, 0x54, 0x45, 0x10, 0x02, 0x22, 0x5b, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56,
+	0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xf7, 0x03, 0x0a, 0x0c, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x18, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46,
+	0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x19, 0x2e,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1a, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x12, 0x13, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4f, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12,
+	0x1c, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d,
+	0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x69, 0x65, 0x77, 0x12, 0x18, 0x2e, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x69, 0x65, 0x77, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x56,
+	0x69, 0x65, 0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x43, 0x0a,
+	0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x18, 0x2e, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x70, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x73, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x63, 0x72, 0x61,
+	0x66, 0x74, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_block_proto_rawDescOnce sync.Once
+	file_block_proto_rawDescData []byte
+)
+
+func file_block_proto_rawDescGZIP() []byte {
+	file_block_proto_rawDescOnce.Do(func() {
+		file_block_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_block_proto_rawDesc), len(file_block_proto_rawDesc)))
+	})
+	return file_block_proto_rawDescData
+}
+
+var file_block_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_block_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_block_proto_goTypes = []any{
+	(UpdateBlockRequest_Precondition)(0), // 0: block.UpdateBlockRequest.Precondition
+	(ViewUpdate_Type)(0),                 // 1: block.ViewUpdate.Type
+	(*ChunkRequest)(nil),                 // 2: block.ChunkRequest
+	(*ChunkUpdate)(nil),                  // 3: block.ChunkUpdate
+	(*FetchChunkRequest)(nil),            // 4: block.FetchChunkRequest
+	(*Block)(nil),                        // 5: block.Block
+	(*FetchChunkResponse)(nil),           // 6: block.FetchChunkResponse
+	(*UpdateBlockRequest)(nil),           // 7: block.UpdateBlockRequest
+	(*UpdateConflict)(nil),               // 8: block.UpdateConflict
+	(*ChunkID)(nil),                      // 9: block.ChunkID
+	(*UpdateBlockResponse)(nil),          // 10: block.UpdateBlockResponse
+	(*BlockType)(nil),                    // 11: block.BlockType
+	(*ListBlockTypesRequest)(nil),        // 12: block.ListBlockTypesRequest
+	(*ListBlockTypesResponse)(nil),       // 13: block.ListBlockTypesResponse
+	(*UpdateBlocksRequest)(nil),          // 14: block.UpdateBlocksRequest
+	(*UpdateBlocksResponse)(nil),         // 15: block.UpdateBlocksResponse
+	(*StreamViewRequest)(nil),            // 16: block.StreamViewRequest
+	(*CachedChunk)(nil),                  // 17: block.CachedChunk
+	(*ViewUpdate)(nil),                   // 18: block.ViewUpdate
+	(*UpdateViewRequest)(nil),            // 19: block.UpdateViewRequest
+	(*UpdateViewResponse)(nil),           // 20: block.UpdateViewResponse
+}
+var file_block_proto_depIdxs = []int32{
+	5,  // 0: block.FetchChunkResponse.blocks:type_name -> block.Block
+	0,  // 1: block.UpdateBlockRequest.precondition:type_name -> block.UpdateBlockRequest.Precondition
+	5,  // 2: block.UpdateConflict.block:type_name -> block.Block
+	9,  // 3: block.UpdateBlockResponse.neighbors:type_name -> block.ChunkID
+	11, // 4: block.ListBlockTypesResponse.types:type_name -> block.BlockType
+	5,  // 5: block.UpdateBlocksRequest.blocks:type_name -> block.Block
+	9,  // 6: block.UpdateBlocksResponse.chunks:type_name -> block.ChunkID
+	17, // 7: block.StreamViewRequest.cached:type_name -> block.CachedChunk
+	1,  // 8: block.ViewUpdate.type:type_name -> block.ViewUpdate.Type
+	5,  // 9: block.ViewUpdate.blocks:type_name -> block.Block
+	4,  // 10: block.BlockService.FetchChunk:input_type -> block.FetchChunkRequest
+	7,  // 11: block.BlockService.UpdateBlock:input_type -> block.UpdateBlockRequest
+	14, // 12: block.BlockService.UpdateBlocks:input_type -> block.UpdateBlocksRequest
+	2,  // 13: block.BlockService.StreamChunk:input_type -> block.ChunkRequest
+	12, // 14: block.BlockService.ListBlockTypes:input_type -> block.ListBlockTypesRequest
+	16, // 15: block.BlockService.StreamView:input_type -> block.StreamViewRequest
+	19, // 16: block.BlockService.UpdateView:input_type -> block.UpdateViewRequest
+	6,  // 17: block.BlockService.FetchChunk:output_type -> block.FetchChunkResponse
+	10, // 18: block.BlockService.UpdateBlock:output_type -> block.UpdateBlockResponse
+	15, // 19: block.BlockService.UpdateBlocks:output_type -> block.UpdateBlocksResponse
+	3,  // 20: block.BlockService.StreamChunk:output_type -> block.ChunkUpdate
+	13, // 21: block.BlockService.ListBlockTypes:output_type -> block.ListBlockTypesResponse
+	18, // 22: block.BlockService.StreamView:output_type -> block.ViewUpdate
+	20, // 23: block.BlockService.UpdateView:output_type -> block.UpdateViewResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_block_proto_init() }
+func file_block_proto_init() {
+	if File_block_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_block_proto_rawDesc), len(file_block_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_block_proto_goTypes,
+		DependencyIndexes: file_block_proto_depIdxs,
+		EnumInfos:         file_block_proto_enumTypes,
+		MessageInfos:      file_block_proto_msgTypes,
+	}.Build()
+	File_block_proto = out.File
+	file_block_proto_goTypes = nil
+	file_block_proto_depIdxs = nil
+}
@@ -0,0 +1,1081 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v5.29.3
+// source: edit.proto
+
+package edit
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Vec3 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z             int32                  `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vec3) Reset() {
+	*x = Vec3{}
+	mi := &file_edit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vec3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vec3) ProtoMessage() {}
+
+func (x *Vec3) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vec3.ProtoReflect.Descriptor instead.
+func (*Vec3) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vec3) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Vec3) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Vec3) GetZ() int32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+type SelectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// pos1 and pos2 are opposite corners, both inside the selection.
+	Pos1 *Vec3 `protobuf:"bytes,1,opt,name=pos1,proto3" json:"pos1,omitempty"`
+	Pos2 *Vec3 `protobuf:"bytes,2,opt,name=pos2,proto3" json:"pos2,omitempty"`
+	// world is the world the edits apply to, empty for the default world.
+	World         string `protobuf:"bytes,3,opt,name=world,proto3" json:"world,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectRequest) Reset() {
+	*x = SelectRequest{}
+	mi := &file_edit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectRequest) ProtoMessage() {}
+
+func (x *SelectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectRequest.ProtoReflect.Descriptor instead.
+func (*SelectRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SelectRequest) GetPos1() *Vec3 {
+	if x != nil {
+		return x.Pos1
+	}
+	return nil
+}
+
+func (x *SelectRequest) GetPos2() *Vec3 {
+	if x != nil {
+		return x.Pos2
+	}
+	return nil
+}
+
+func (x *SelectRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+type SelectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           *Vec3                  `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max           *Vec3                  `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	Volume        int64                  `protobuf:"varint,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectResponse) Reset() {
+	*x = SelectResponse{}
+	mi := &file_edit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectResponse) ProtoMessage() {}
+
+func (x *SelectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectResponse.ProtoReflect.Descriptor instead.
+func (*SelectResponse) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SelectResponse) GetMin() *Vec3 {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *SelectResponse) GetMax() *Vec3 {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *SelectResponse) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+type FillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	W             int32                  `protobuf:"varint,1,opt,name=w,proto3" json:"w,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FillRequest) Reset() {
+	*x = FillRequest{}
+	mi := &file_edit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FillRequest) ProtoMessage() {}
+
+func (x *FillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FillRequest.ProtoReflect.Descriptor instead.
+func (*FillRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FillRequest) GetW() int32 {
+	if x != nil {
+		return x.W
+	}
+	return 0
+}
+
+type ReplaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int32                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            int32                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceRequest) Reset() {
+	*x = ReplaceRequest{}
+	mi := &file_edit_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceRequest) ProtoMessage() {}
+
+func (x *ReplaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplaceRequest) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ReplaceRequest) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type HollowRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// w fills the inside, 0 (air) by default.
+	W             int32 `protobuf:"varint,1,opt,name=w,proto3" json:"w,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HollowRequest) Reset() {
+	*x = HollowRequest{}
+	mi := &file_edit_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HollowRequest) ProtoMessage() {}
+
+func (x *HollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HollowRequest.ProtoReflect.Descriptor instead.
+func (*HollowRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HollowRequest) GetW() int32 {
+	if x != nil {
+		return x.W
+	}
+	return 0
+}
+
+type WallsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	W             int32                  `protobuf:"varint,1,opt,name=w,proto3" json:"w,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WallsRequest) Reset() {
+	*x = WallsRequest{}
+	mi := &file_edit_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WallsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WallsRequest) ProtoMessage() {}
+
+func (x *WallsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WallsRequest.ProtoReflect.Descriptor instead.
+func (*WallsRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WallsRequest) GetW() int32 {
+	if x != nil {
+		return x.W
+	}
+	return 0
+}
+
+type CopyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyRequest) Reset() {
+	*x = CopyRequest{}
+	mi := &file_edit_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyRequest) ProtoMessage() {}
+
+func (x *CopyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyRequest.ProtoReflect.Descriptor instead.
+func (*CopyRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{7}
+}
+
+type CopyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// blocks is the number of blocks copied.
+	Blocks        int32 `protobuf:"varint,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	Size          *Vec3 `protobuf:"bytes,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyResponse) Reset() {
+	*x = CopyResponse{}
+	mi := &file_edit_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyResponse) ProtoMessage() {}
+
+func (x *CopyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyResponse.ProtoReflect.Descriptor instead.
+func (*CopyResponse) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CopyResponse) GetBlocks() int32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *CopyResponse) GetSize() *Vec3 {
+	if x != nil {
+		return x.Size
+	}
+	return nil
+}
+
+type PasteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// at is where the minimum corner of the pasted blocks goes.
+	At *Vec3 `protobuf:"bytes,1,opt,name=at,proto3" json:"at,omitempty"`
+	// rotation is the clockwise rotation around the y axis in degrees, a
+	// multiple of 90. Mirroring is applied first.
+	Rotation      int32 `protobuf:"varint,2,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	MirrorX       bool  `protobuf:"varint,3,opt,name=mirror_x,json=mirrorX,proto3" json:"mirror_x,omitempty"`
+	MirrorZ       bool  `protobuf:"varint,4,opt,name=mirror_z,json=mirrorZ,proto3" json:"mirror_z,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasteRequest) Reset() {
+	*x = PasteRequest{}
+	mi := &file_edit_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasteRequest) ProtoMessage() {}
+
+func (x *PasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasteRequest.ProtoReflect.Descriptor instead.
+func (*PasteRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PasteRequest) GetAt() *Vec3 {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *PasteRequest) GetRotation() int32 {
+	if x != nil {
+		return x.Rotation
+	}
+	return 0
+}
+
+func (x *PasteRequest) GetMirrorX() bool {
+	if x != nil {
+		return x.MirrorX
+	}
+	return false
+}
+
+func (x *PasteRequest) GetMirrorZ() bool {
+	if x != nil {
+		return x.MirrorZ
+	}
+	return false
+}
+
+type MoveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        *Vec3                  `protobuf:"bytes,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveRequest) Reset() {
+	*x = MoveRequest{}
+	mi := &file_edit_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRequest) ProtoMessage() {}
+
+func (x *MoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRequest.ProtoReflect.Descriptor instead.
+func (*MoveRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MoveRequest) GetOffset() *Vec3 {
+	if x != nil {
+		return x.Offset
+	}
+	return nil
+}
+
+type UndoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndoRequest) Reset() {
+	*x = UndoRequest{}
+	mi := &file_edit_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoRequest) ProtoMessage() {}
+
+func (x *UndoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoRequest.ProtoReflect.Descriptor instead.
+func (*UndoRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{11}
+}
+
+type RedoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedoRequest) Reset() {
+	*x = RedoRequest{}
+	mi := &file_edit_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedoRequest) ProtoMessage() {}
+
+func (x *RedoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedoRequest.ProtoReflect.Descriptor instead.
+func (*RedoRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{12}
+}
+
+type ExportSchematicRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSchematicRequest) Reset() {
+	*x = ExportSchematicRequest{}
+	mi := &file_edit_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSchematicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSchematicRequest) ProtoMessage() {}
+
+func (x *ExportSchematicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSchematicRequest.ProtoReflect.Descriptor instead.
+func (*ExportSchematicRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{13}
+}
+
+type ExportSchematicResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Schematic []byte                 `protobuf:"bytes,1,opt,name=schematic,proto3" json:"schematic,omitempty"`
+	// blocks is the number of blocks exported.
+	Blocks        int32 `protobuf:"varint,2,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSchematicResponse) Reset() {
+	*x = ExportSchematicResponse{}
+	mi := &file_edit_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSchematicResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSchematicResponse) ProtoMessage() {}
+
+func (x *ExportSchematicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSchematicResponse.ProtoReflect.Descriptor instead.
+func (*ExportSchematicResponse) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExportSchematicResponse) GetSchematic() []byte {
+	if x != nil {
+		return x.Schematic
+	}
+	return nil
+}
+
+func (x *ExportSchematicResponse) GetBlocks() int32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+type ImportSchematicRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Schematic []byte                 `protobuf:"bytes,1,opt,name=schematic,proto3" json:"schematic,omitempty"`
+	// at is where the minimum corner of the schematic goes.
+	At *Vec3 `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	// rotation is the clockwise rotation around the y axis in degrees, a
+	// multiple of 90.
+	Rotation      int32 `protobuf:"varint,3,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSchematicRequest) Reset() {
+	*x = ImportSchematicRequest{}
+	mi := &file_edit_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSchematicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSchematicRequest) ProtoMessage() {}
+
+func (x *ImportSchematicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSchematicRequest.ProtoReflect.Descriptor instead.
+func (*ImportSchematicRequest) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportSchematicRequest) GetSchematic() []byte {
+	if x != nil {
+		return x.Schematic
+	}
+	return nil
+}
+
+func (x *ImportSchematicRequest) GetAt() *Vec3 {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *ImportSchematicRequest) GetRotation() int32 {
+	if x != nil {
+		return x.Rotation
+	}
+	return 0
+}
+
+type EditResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// changed is the number of blocks written.
+	Changed int32 `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`
+	// version is the chunk version of the change.
+	Version       string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditResponse) Reset() {
+	*x = EditResponse{}
+	mi := &file_edit_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditResponse) ProtoMessage() {}
+
+func (x *EditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_edit_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditResponse.ProtoReflect.Descriptor instead.
+func (*EditResponse) Descriptor() ([]byte, []int) {
+	return file_edit_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EditResponse) GetChanged() int32 {
+	if x != nil {
+		return x.Changed
+	}
+	return 0
+}
+
+func (x *EditResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+var File_edit_proto protoreflect.FileDescriptor
+
+var file_edit_proto_rawDesc = string([]byte{
+	0x0a, 0x0a, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x65, 0x64,
+	0x69, 0x74, 0x22, 0x30, 0x0a, 0x04, 0x56, 0x65, 0x63, 0x33, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x01, 0x7a, 0x22, 0x65, 0x0a, 0x0d, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x31, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x65, 0x63, 0x33, 0x52,
+	0x04, 0x70, 0x6f, 0x73, 0x31, 0x12, 0x1e, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x32, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x65, 0x63, 0x33, 0x52,
+	0x04, 0x70, 0x6f, 0x73, 0x32, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x22, 0x64, 0x0a, 0x0e, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x69,
+	0x74, 0x2e, 0x56, 0x65, 0x63, 0x33, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x03, 0x6d,
+	0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e,
+	0x56, 0x65, 0x63, 0x33, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x22, 0x1b, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0c, 0x0a, 0x01, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x77, 0x22, 0x34,
+	0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x74, 0x6f, 0x22, 0x1d, 0x0a, 0x0d, 0x48, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x01, 0x77, 0x22, 0x1c, 0x0a, 0x0c, 0x57, 0x61, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01,
+	0x77, 0x22, 0x0d, 0x0a, 0x0b, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x46, 0x0a, 0x0c, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1e, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x65,
+	0x63, 0x33, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x7c, 0x0a, 0x0c, 0x50, 0x61, 0x73, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x65, 0x63, 0x33,
+	0x52, 0x02, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x58, 0x12, 0x19, 0x0a, 0x08, 0x6d,
+	0x69, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x7a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d,
+	0x69, 0x72, 0x72, 0x6f, 0x72, 0x5a, 0x22, 0x31, 0x0a, 0x0b, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x65, 0x63,
+	0x33, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x0d, 0x0a, 0x0b, 0x55, 0x6e, 0x64,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x0d, 0x0a, 0x0b, 0x52, 0x65, 0x64, 0x6f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x4f, 0x0a, 0x17, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x74, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x22, 0x6e, 0x0a, 0x16, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x74, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x12, 0x1a, 0x0a, 0x02, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x65,
+	0x63, 0x33, 0x52, 0x02, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x42, 0x0a, 0x0c, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0xa4, 0x05, 0x0a, 0x0b, 0x45, 0x64, 0x69, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x12, 0x13, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a,
+	0x04, 0x46, 0x69, 0x6c, 0x6c, 0x12, 0x11, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x46, 0x69, 0x6c,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e,
+	0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x35,
+	0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x12, 0x14, 0x2e, 0x65, 0x64, 0x69, 0x74,
+	0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x06, 0x48, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12,
+	0x13, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x48, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x45, 0x64, 0x69, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x05, 0x57, 0x61,
+	0x6c, 0x6c, 0x73, 0x12, 0x12, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x45,
+	0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a,
+	0x04, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x11, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x70,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e,
+	0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31,
+	0x0a, 0x05, 0x50, 0x61, 0x73, 0x74, 0x65, 0x12, 0x12, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x50,
+	0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65, 0x64,
+	0x69, 0x74, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x2f, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x11, 0x2e, 0x65, 0x64, 0x69, 0x74,
+	0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65,
+	0x64, 0x69, 0x74, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x2f, 0x0a, 0x04, 0x55, 0x6e, 0x64, 0x6f, 0x12, 0x11, 0x2e, 0x65, 0x64, 0x69,
+	0x74, 0x2e, 0x55, 0x6e, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x65, 0x64, 0x69, 0x74, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x04, 0x52, 0x65, 0x64, 0x6f, 0x12, 0x11, 0x2e, 0x65, 0x64,
+	0x69, 0x74, 0x2e, 0x52, 0x65, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x12, 0x1c, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x12, 0x1c, 0x2e, 0x65, 0x64, 0x69, 0x74,
+	0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x45,
+	0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x30, 0x5a,
+	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x65, 0x72, 0x6c,
+	0x69, 0x6e, 0x73, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x63, 0x72, 0x61, 0x66, 0x74, 0x2d, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x64, 0x69, 0x74, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_edit_proto_rawDescOnce sync.Once
+	file_edit_proto_rawDescData []byte
+)
+
+func file_edit_proto_rawDescGZIP() []byte {
+	file_edit_proto_rawDescOnce.Do(func() {
+		file_edit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_edit_proto_rawDesc), len(file_edit_proto_rawDesc)))
+	})
+	return file_edit_proto_rawDescData
+}
+
+var file_edit_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_edit_proto_goTypes = []any{
+	(*Vec3)(nil),                    // 0: edit.Vec3
+	(*SelectRequest)(nil),           // 1: edit.SelectRequest
+	(*SelectResponse)(nil),          // 2: edit.SelectResponse
+	(*FillRequest)(nil),             // 3: edit.FillRequest
+	(*ReplaceRequest)(nil),          // 4: edit.ReplaceRequest
+	(*HollowRequest)(nil),           // 5: edit.HollowRequest
+	(*WallsRequest)(nil),            // 6: edit.WallsRequest
+	(*CopyRequest)(nil),             // 7: edit.CopyRequest
+	(*CopyResponse)(nil),            // 8: edit.CopyResponse
+	(*PasteRequest)(nil),            // 9: edit.PasteRequest
+	(*MoveRequest)(nil),             // 10: edit.MoveRequest
+	(*UndoRequest)(nil),             // 11: edit.UndoRequest
+	(*RedoRequest)(nil),             // 12: edit.RedoRequest
+	(*ExportSchematicRequest)(nil),  // 13: edit.ExportSchematicRequest
+	(*ExportSchematicResponse)(nil), // 14: edit.ExportSchematicResponse
+	(*ImportSchematicRequest)(nil),  // 15: edit.ImportSchematicRequest
+	(*EditResponse)(nil),            // 16: edit.EditResponse
+}
+var file_edit_proto_depIdxs = []int32{
+	0,  // 0: edit.SelectRequest.pos1:type_name -> edit.Vec3
+	0,  // 1: edit.SelectRequest.pos2:type_name -> edit.Vec3
+	0,  // 2: edit.SelectResponse.min:type_name -> edit.Vec3
+	0,  // 3: edit.SelectResponse.max:type_name -> edit.Vec3
+	0,  // 4: edit.CopyResponse.size:type_name -> edit.Vec3
+	0,  // 5: edit.PasteRequest.at:type_name -> edit.Vec3
+	0,  // 6: edit.MoveRequest.offset:type_name -> edit.Vec3
+	0,  // 7: edit.ImportSchematicRequest.at:type_name -> edit.Vec3
+	1,  // 8: edit.EditService.Select:input_type -> edit.SelectRequest
+	3,  // 9: edit.EditService.Fill:input_type -> edit.FillRequest
+	4,  // 10: edit.EditService.Replace:input_type -> edit.ReplaceRequest
+	5,  // 11: edit.EditService.Hollow:input_type -> edit.HollowRequest
+	6,  // 12: edit.EditService.Walls:input_type -> edit.WallsRequest
+	7,  // 13: edit.EditService.Copy:input_type -> edit.CopyRequest
+	9,  // 14: edit.EditService.Paste:input_type -> edit.PasteRequest
+	10, // 15: edit.EditService.Move:input_type -> edit.MoveRequest
+	11, // 16: edit.EditService.Undo:input_type -> edit.UndoRequest
+	12, // 17: edit.EditService.Redo:input_type -> edit.RedoRequest
+	13, // 18: edit.EditService.ExportSchematic:input_type -> edit.ExportSchematicRequest
+	15, // 19: edit.EditService.ImportSchematic:input_type -> edit.ImportSchematicRequest
+	2,  // 20: edit.EditService.Select:output_type -> edit.SelectResponse
+	16, // 21: edit.EditService.Fill:output_type -> edit.EditResponse
+	16, // 22: edit.EditService.Replace:output_type -> edit.EditResponse
+	16, // 23: edit.EditService.Hollow:output_type -> edit.EditResponse
+	16, // 24: edit.EditService.Walls:output_type -> edit.EditResponse
+	8,  // 25: edit.EditService.Copy:output_type -> edit.CopyResponse
+	16, // 26: edit.EditService.Paste:output_type -> edit.EditResponse
+	16, // 27: edit.EditService.Move:output_type -> edit.EditResponse
+	16, // 28: edit.EditService.Undo:output_type -> edit.EditResponse
+	16, // 29: edit.EditService.Redo:output_type -> edit.EditResponse
+	14, // 30: edit.EditService.ExportSchematic:output_type -> edit.ExportSchematicResponse
+	16, // 31: edit.EditService.ImportSchematic:output_type -> edit.EditResponse
+	20, // [20:32] is the sub-list for method output_type
+	8,  // [8:20] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_edit_proto_init() }
+func file_edit_proto_init() {
+	if File_edit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_edit_proto_rawDesc), len(file_edit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_edit_proto_goTypes,
+		DependencyIndexes: file_edit_proto_depIdxs,
+		MessageInfos:      file_edit_proto_msgTypes,
+	}.Build()
+	File_edit_proto = out.File
+	file_edit_proto_goTypes = nil
+	file_edit_proto_depIdxs = nil
+}
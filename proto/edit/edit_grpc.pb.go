@@ -0,0 +1,567 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: edit.proto
+
+package edit
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EditService_Select_FullMethodName          = "/edit.EditService/Select"
+	EditService_Fill_FullMethodName            = "/edit.EditService/Fill"
+	EditService_Replace_FullMethodName         = "/edit.EditService/Replace"
+	EditService_Hollow_FullMethodName          = "/edit.EditService/Hollow"
+	EditService_Walls_FullMethodName           = "/edit.EditService/Walls"
+	EditService_Copy_FullMethodName            = "/edit.EditService/Copy"
+	EditService_Paste_FullMethodName           = "/edit.EditService/Paste"
+	EditService_Move_FullMethodName            = "/edit.EditService/Move"
+	EditService_Undo_FullMethodName            = "/edit.EditService/Undo"
+	EditService_Redo_FullMethodName            = "/edit.EditService/Redo"
+	EditService_ExportSchematic_FullMethodName = "/edit.EditService/ExportSchematic"
+	EditService_ImportSchematic_FullMethodName = "/edit.EditService/ImportSchematic"
+)
+
+// EditServiceClient is the client API for EditService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EditService offers region operations to builders. Every user has an edit
+// session holding a selection, a clipboard and an undo history. The server
+// only knows the blocks players changed, not the generated terrain, so
+// replace, copy and move only see those.
+type EditServiceClient interface {
+	// Select sets the cuboid the other operations work on.
+	Select(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*SelectResponse, error)
+	Fill(ctx context.Context, in *FillRequest, opts ...grpc.CallOption) (*EditResponse, error)
+	Replace(ctx context.Context, in *ReplaceRequest, opts ...grpc.CallOption) (*EditResponse, error)
+	// Hollow fills the inside of the selection, keeping a one block shell.
+	Hollow(ctx context.Context, in *HollowRequest, opts ...grpc.CallOption) (*EditResponse, error)
+	// Walls fills the four vertical sides of the selection.
+	Walls(ctx context.Context, in *WallsRequest, opts ...grpc.CallOption) (*EditResponse, error)
+	Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (*CopyResponse, error)
+	Paste(ctx context.Context, in *PasteRequest, opts ...grpc.CallOption) (*EditResponse, error)
+	// Move moves the blocks of the selection and the selection itself,
+	// leaving air behind.
+	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*EditResponse, error)
+	Undo(ctx context.Context, in *UndoRequest, opts ...grpc.CallOption) (*EditResponse, error)
+	Redo(ctx context.Context, in *RedoRequest, opts ...grpc.CallOption) (*EditResponse, error)
+	// ExportSchematic returns the selection as a schematic file, see
+	// internal/schematic for the format. Admins only.
+	ExportSchematic(ctx context.Context, in *ExportSchematicRequest, opts ...grpc.CallOption) (*ExportSchematicResponse, error)
+	// ImportSchematic pastes a schematic file, it can be undone. Admins
+	// only.
+	ImportSchematic(ctx context.Context, in *ImportSchematicRequest, opts ...grpc.CallOption) (*EditResponse, error)
+}
+
+type editServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEditServiceClient(cc grpc.ClientConnInterface) EditServiceClient {
+	return &editServiceClient{cc}
+}
+
+func (c *editServiceClient) Select(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*SelectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SelectResponse)
+	err := c.cc.Invoke(ctx, EditService_Select_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Fill(ctx context.Context, in *FillRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_Fill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Replace(ctx context.Context, in *ReplaceRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_Replace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Hollow(ctx context.Context, in *HollowRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_Hollow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Walls(ctx context.Context, in *WallsRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_Walls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (*CopyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CopyResponse)
+	err := c.cc.Invoke(ctx, EditService_Copy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Paste(ctx context.Context, in *PasteRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_Paste_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_Move_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Undo(ctx context.Context, in *UndoRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_Undo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) Redo(ctx context.Context, in *RedoRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_Redo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) ExportSchematic(ctx context.Context, in *ExportSchematicRequest, opts ...grpc.CallOption) (*ExportSchematicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportSchematicResponse)
+	err := c.cc.Invoke(ctx, EditService_ExportSchematic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *editServiceClient) ImportSchematic(ctx context.Context, in *ImportSchematicRequest, opts ...grpc.CallOption) (*EditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditResponse)
+	err := c.cc.Invoke(ctx, EditService_ImportSchematic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EditServiceServer is the server API for EditService service.
+// All implementations must embed UnimplementedEditServiceServer
+// for forward compatibility.
+//
+// EditService offers region operations to builders. Every user has an edit
+// session holding a selection, a clipboard and an undo history. The server
+// only knows the blocks players changed, not the generated terrain, so
+// replace, copy and move only see those.
+type EditServiceServer interface {
+	// Select sets the cuboid the other operations work on.
+	Select(context.Context, *SelectRequest) (*SelectResponse, error)
+	Fill(context.Context, *FillRequest) (*EditResponse, error)
+	Replace(context.Context, *ReplaceRequest) (*EditResponse, error)
+	// Hollow fills the inside of the selection, keeping a one block shell.
+	Hollow(context.Context, *HollowRequest) (*EditResponse, error)
+	// Walls fills the four vertical sides of the selection.
+	Walls(context.Context, *WallsRequest) (*EditResponse, error)
+	Copy(context.Context, *CopyRequest) (*CopyResponse, error)
+	Paste(context.Context, *PasteRequest) (*EditResponse, error)
+	// Move moves the blocks of the selection and the selection itself,
+	// leaving air behind.
+	Move(context.Context, *MoveRequest) (*EditResponse, error)
+	Undo(context.Context, *UndoRequest) (*EditResponse, error)
+	Redo(context.Context, *RedoRequest) (*EditResponse, error)
+	// ExportSchematic returns the selection as a schematic file, see
+	// internal/schematic for the format. Admins only.
+	ExportSchematic(context.Context, *ExportSchematicRequest) (*ExportSchematicResponse, error)
+	// ImportSchematic pastes a schematic file, it can be undone. Admins
+	// only.
+	ImportSchematic(context.Context, *ImportSchematicRequest) (*EditResponse, error)
+	mustEmbedUnimplementedEditServiceServer()
+}
+
+// UnimplementedEditServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEditServiceServer struct{}
+
+func (UnimplementedEditServiceServer) Select(context.Context, *SelectRequest) (*SelectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Select not implemented")
+}
+func (UnimplementedEditServiceServer) Fill(context.Context, *FillRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fill not implemented")
+}
+func (UnimplementedEditServiceServer) Replace(context.Context, *ReplaceRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replace not implemented")
+}
+func (UnimplementedEditServiceServer) Hollow(context.Context, *HollowRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Hollow not implemented")
+}
+func (UnimplementedEditServiceServer) Walls(context.Context, *WallsRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Walls not implemented")
+}
+func (UnimplementedEditServiceServer) Copy(context.Context, *CopyRequest) (*CopyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Copy not implemented")
+}
+func (UnimplementedEditServiceServer) Paste(context.Context, *PasteRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Paste not implemented")
+}
+func (UnimplementedEditServiceServer) Move(context.Context, *MoveRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Move not implemented")
+}
+func (UnimplementedEditServiceServer) Undo(context.Context, *UndoRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Undo not implemented")
+}
+func (UnimplementedEditServiceServer) Redo(context.Context, *RedoRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Redo not implemented")
+}
+func (UnimplementedEditServiceServer) ExportSchematic(context.Context, *ExportSchematicRequest) (*ExportSchematicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSchematic not implemented")
+}
+func (UnimplementedEditServiceServer) ImportSchematic(context.Context, *ImportSchematicRequest) (*EditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSchematic not implemented")
+}
+func (UnimplementedEditServiceServer) mustEmbedUnimplementedEditServiceServer() {}
+func (UnimplementedEditServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeEditServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EditServiceServer will
+// result in compilation errors.
+type UnsafeEditServiceServer interface {
+	mustEmbedUnimplementedEditServiceServer()
+}
+
+func RegisterEditServiceServer(s grpc.ServiceRegistrar, srv EditServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEditServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EditService_ServiceDesc, srv)
+}
+
+func _EditService_Select_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Select(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Select_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Select(ctx, req.(*SelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Fill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Fill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Fill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Fill(ctx, req.(*FillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Replace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Replace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Replace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Replace(ctx, req.(*ReplaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Hollow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Hollow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Hollow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Hollow(ctx, req.(*HollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Walls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WallsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Walls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Walls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Walls(ctx, req.(*WallsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Copy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Copy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Copy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Copy(ctx, req.(*CopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Paste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Paste(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Paste_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Paste(ctx, req.(*PasteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Move_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Move(ctx, req.(*MoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Undo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Undo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Undo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Undo(ctx, req.(*UndoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_Redo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).Redo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_Redo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).Redo(ctx, req.(*RedoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_ExportSchematic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSchematicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).ExportSchematic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_ExportSchematic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).ExportSchematic(ctx, req.(*ExportSchematicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EditService_ImportSchematic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSchematicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditServiceServer).ImportSchematic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditService_ImportSchematic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditServiceServer).ImportSchematic(ctx, req.(*ImportSchematicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EditService_ServiceDesc is the grpc.ServiceDesc for EditService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EditService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "edit.EditService",
+	HandlerType: (*EditServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Select",
+			Handler:    _EditService_Select_Handler,
+		},
+		{
+			MethodName: "Fill",
+			Handler:    _EditService_Fill_Handler,
+		},
+		{
+			MethodName: "Replace",
+			Handler:    _EditService_Replace_Handler,
+		},
+		{
+			MethodName: "Hollow",
+			Handler:    _EditService_Hollow_Handler,
+		},
+		{
+			MethodName: "Walls",
+			Handler:    _EditService_Walls_Handler,
+		},
+		{
+			MethodName: "Copy",
+			Handler:    _EditService_Copy_Handler,
+		},
+		{
+			MethodName: "Paste",
+			Handler:    _EditService_Paste_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _EditService_Move_Handler,
+		},
+		{
+			MethodName: "Undo",
+			Handler:    _EditService_Undo_Handler,
+		},
+		{
+			MethodName: "Redo",
+			Handler:    _EditService_Redo_Handler,
+		},
+		{
+			MethodName: "ExportSchematic",
+			Handler:    _EditService_ExportSchematic_Handler,
+		},
+		{
+			MethodName: "ImportSchematic",
+			Handler:    _EditService_ImportSchematic_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "edit.proto",
+}
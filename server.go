@@ -1,65 +1,77 @@
-package main
+package server
 
 import (
+	"context"
 	"encoding/binary"
-	"log"
+	"log/slog"
 	"net"
 	"net/rpc"
 	"net/rpc/jsonrpc"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/yamux"
 )
 
 type Server struct {
-	clientid  int32
-	sessions  sync.Map // map[id]*Session
-	rpcServer *rpc.Server
+	clientid    int32
+	sessions    sync.Map // map[id]*Session
+	rpcServer   *rpc.Server
+	yamuxConfig *yamux.Config
 
 	playerCallback func(string, int32)
+	admit          func(ip string) error
 }
 
 func NewServer() *Server {
 	return &Server{
-		rpcServer: rpc.NewServer(),
+		rpcServer:   rpc.NewServer(),
+		yamuxConfig: yamux.DefaultConfig(),
 	}
 }
 
-func (s *Server) serveRpc(sess *yamux.Session) {
+func (s *Server) serveRpc(sess *yamux.Session, session *Session) {
 	conn, err := sess.Accept()
 	if err != nil {
-		log.Print(err)
+		slog.Warn("legacy rpc stream accept failed", "error", err)
 		return
 	}
-	s.rpcServer.ServeCodec(jsonrpc.NewServerCodec(conn))
+	s.rpcServer.ServeCodec(jsonrpc.NewServerCodec(&activityConn{Conn: conn, sess: session}))
 }
 
 func (s *Server) handleConn(conn net.Conn) {
 	defer conn.Close()
 	id := atomic.AddInt32(&s.clientid, 1)
-	log.Printf("allocated %d for %s", id, conn.RemoteAddr())
+	logger := slog.With("session_id", id, "remote", conn.RemoteAddr().String())
+	if s.admit != nil {
+		if err := s.admit(remoteIP(conn)); err != nil {
+			logger.Info("legacy client refused", "reason", err)
+			return
+		}
+	}
+	logger.Info("legacy client connected")
 	// send id to client, handshake done.
 	binary.Write(conn, binary.BigEndian, id)
 
-	sess, err := yamux.Server(conn, nil)
+	sess, err := yamux.Server(conn, s.yamuxConfig)
 	if err != nil {
-		log.Print(err)
+		logger.Warn("yamux handshake failed", "error", err)
 		return
 	}
 
 	clientConn, err := sess.Open()
 	if err != nil {
-		log.Print(err)
+		logger.Warn("opening client rpc stream failed", "error", err)
 		return
 	}
 	session := NewSession(conn, clientConn)
 	s.sessions.Store(id, session)
-	s.playerCallback("online", id)
-	s.serveRpc(sess)
+	s.notifyPlayer("online", id)
+	s.serveRpc(sess, session)
 	s.sessions.Delete(id)
-	s.playerCallback("offline", id)
-	log.Printf("%s(%d) closed connection", conn.RemoteAddr(), id)
+	s.notifyPlayer("offline", id)
+	logger.Info("legacy client closed connection")
 }
 
 func (s *Server) RegisterService(name string, service interface{}) error {
@@ -73,15 +85,111 @@ func (s *Server) RangeSession(f func(id int32, sess *Session)) {
 	})
 }
 
+// Session returns the session of client id.
+func (s *Server) Session(id int32) (*Session, bool) {
+	sess, ok := s.sessions.Load(id)
+	if !ok {
+		return nil, false
+	}
+	return sess.(*Session), true
+}
+
+// SessionCount returns the number of connected sessions.
+func (s *Server) SessionCount() int {
+	n := 0
+	s.sessions.Range(func(k, v interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// SetAdmission makes the server refuse clients for which admit returns an
+// error, e.g. banned addresses. Refused clients are disconnected before
+// the handshake.
+func (s *Server) SetAdmission(admit func(ip string) error) {
+	s.admit = admit
+}
+
+// Kick closes the session of client id and reports whether it existed.
+// The client is told the reason by a call of its "Server.Kick" method,
+// waiting at most kickTimeout for clients that don't serve it.
+func (s *Server) Kick(id int32, reason string) bool {
+	sess, ok := s.Session(id)
+	if !ok {
+		return false
+	}
+	slog.Info("kicking legacy client", "session_id", id, "reason", reason)
+	sess.Kick(reason)
+	return true
+}
+
+// KickIP kicks every session from ip and returns how many there were.
+func (s *Server) KickIP(ip, reason string) int {
+	n := 0
+	s.RangeSession(func(id int32, sess *Session) {
+		if remoteIP(sess.masterConn) == ip {
+			s.Kick(id, reason)
+			n++
+		}
+	})
+	return n
+}
+
+func remoteIP(conn net.Conn) string {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
+
 func (s *Server) SetPlayerCallback(callback func(string, int32)) {
 	s.playerCallback = callback
 }
 
+func (s *Server) notifyPlayer(event string, id int32) {
+	if s.playerCallback != nil {
+		s.playerCallback(event, id)
+	}
+}
+
+// SetKeepAlive enables yamux keepalive pings every interval. A session
+// whose pings or writes do not complete within timeout is torn down.
+func (s *Server) SetKeepAlive(interval, timeout time.Duration) {
+	config := yamux.DefaultConfig()
+	config.EnableKeepAlive = true
+	config.KeepAliveInterval = interval
+	config.ConnectionWriteTimeout = timeout
+	s.yamuxConfig = config
+}
+
+// ReapIdle closes sessions that have not sent anything for longer than
+// idle, checking every interval until ctx is done. Closing a session
+// ends handleConn, which reports the player offline.
+func (s *Server) ReapIdle(ctx context.Context, idle, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.RangeSession(func(id int32, sess *Session) {
+				if now.Sub(sess.LastSeen()) > idle {
+					slog.Info("closing idle legacy session", "session_id", id, "idle", now.Sub(sess.LastSeen()))
+					sess.Close()
+				}
+			})
+		}
+	}
+}
+
 func (s *Server) Serve(l net.Listener) {
 	for {
 		conn, err := l.Accept()
 		if err != nil {
-			log.Print(err)
+			slog.Error("legacy accept failed", "error", err)
 			continue
 		}
 		go s.handleConn(conn)
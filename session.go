@@ -1,24 +1,69 @@
-package main
+package server
 
 import (
 	"net"
 	"net/rpc"
 	"net/rpc/jsonrpc"
+	"sync/atomic"
+	"time"
 )
 
 type Session struct {
 	masterConn net.Conn
 	*rpc.Client
+
+	lastSeen int64 // unix nano, accessed atomically
 }
 
 func NewSession(masterConn, clientConn net.Conn) *Session {
-	return &Session{
+	s := &Session{
 		masterConn: masterConn,
 		Client:     rpc.NewClientWithCodec(jsonrpc.NewClientCodec(clientConn)),
 	}
+	s.Touch()
+	return s
+}
+
+// Touch marks the session as alive.
+func (s *Session) Touch() {
+	atomic.StoreInt64(&s.lastSeen, time.Now().UnixNano())
+}
+
+// LastSeen returns the last time the client sent anything.
+func (s *Session) LastSeen() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&s.lastSeen))
+}
+
+// kickTimeout bounds the wait for a client to take note of a kick.
+const kickTimeout = time.Second
+
+// Kick tells the client the reason with a call of its "Server.Kick"
+// method and closes the session.
+func (s *Session) Kick(reason string) {
+	var ok bool
+	call := s.Client.Go("Server.Kick", reason, &ok, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+	case <-time.After(kickTimeout):
+	}
+	s.Close()
 }
 
 func (s *Session) Close() {
 	s.Client.Close()
 	s.masterConn.Close()
 }
+
+// activityConn touches its session on every successful read.
+type activityConn struct {
+	net.Conn
+	sess *Session
+}
+
+func (c *activityConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.sess.Touch()
+	}
+	return n, err
+}